@@ -51,9 +51,43 @@ var addClientCmd = &cobra.Command{
 	},
 }
 
+var addProjectCmd = &cobra.Command{
+	Use:   "project [name] [price]",
+	Short: "add a project to a client",
+	Long: `Add a project to a client. The price is optional, and overrides the
+    client's hourly rate for sessions of this project. The currency is
+    inherited from the client.`,
+	Args: cobra.RangeArgs(1, 2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return GetClientIfExists(currentClientName)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		newProject := models.Project{
+			Name:   args[0],
+			Client: *currentClient,
+		}
+		if len(args) == 2 {
+			price, err := strconv.ParseInt(args[1], 10, 32)
+			if err != nil || price < 0 {
+				return fmt.Errorf("invalid price %s", args[1])
+			}
+			pph := uint16(price)
+			newProject.PPH = &pph
+		}
+		err := ProjectRepository.Insert(&newProject)
+		if err != nil {
+			return fmt.Errorf("unable to insert project: %v", err)
+		}
+		rootCmd.Printf("Added project %s to %s\n", newProject.Name, currentClient.Name)
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(addCmd)
 	addCmd.AddCommand(addClientCmd)
+	addCmd.AddCommand(addProjectCmd)
 	addClientCmd.Flags().StringVar(&currency, "currency", "",
 		"currency in which the client pays (defaults to USD)")
+	addProjectCmd.Flags().StringVarP(&currentClientName, "client", "c", "", "Specify the client name")
 }
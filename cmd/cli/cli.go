@@ -19,19 +19,28 @@ import (
 
 // common instances
 var (
-	Config            *config.Config
-	SessionRepository repositories.SessionRepository
-	ClientRepository  repositories.ClientRepository
-	Puncher           *puncher.Puncher
-	Source            *sync.SyncSource
+	Config                 *config.Config
+	SessionRepository      repositories.SessionRepository
+	ClientRepository       repositories.ClientRepository
+	ProjectRepository      repositories.ProjectRepository
+	InvoiceRepository      repositories.InvoiceRepository
+	ExchangeRateRepository repositories.ExchangeRateRepository
+	SyncSnapshotRepository repositories.SyncSnapshotRepository
+	Transaction            func(fn func(repos repositories.Repositories) error) error
+	Puncher                *puncher.Puncher
+	Source                 *sync.SyncSource
+	SourceName             string
 )
 
 // cli flags
 var (
-	currentClientName string
-	currentClient     *models.Client
-	punchMessage      string
-	verbose           bool
+	currentClientName  string
+	currentClient      *models.Client
+	currentProjectName string
+	currentProject     *models.Project
+	punchMessage       string
+	punchTags          []string
+	verbose            bool
 )
 
 var rootCmd = &cobra.Command{
@@ -40,10 +49,14 @@ var rootCmd = &cobra.Command{
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		return GetClientIfExists(currentClientName)
+		err := GetClientIfExists(currentClientName)
+		if err != nil {
+			return err
+		}
+		return GetProjectIfExists(currentProjectName)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		session, err := Puncher.ToggleCheckInOut(currentClient, punchMessage)
+		session, err := Puncher.ToggleCheckInOut(currentClient, currentProject, punchMessage, punchTags...)
 		if err != nil {
 			return err
 		}
@@ -91,7 +104,9 @@ var configCmd = &cobra.Command{
 
 func init() {
 	rootCmd.Flags().StringVarP(&currentClientName, "client", "c", "", "Specify a client's name")
+	rootCmd.Flags().StringVarP(&currentProjectName, "project", "p", "", "Specify the client's project")
 	rootCmd.Flags().StringVarP(&punchMessage, "message", "m", "", "Comment or message")
+	rootCmd.Flags().StringSliceVarP(&punchTags, "tag", "t", nil, "Tag the session (can be repeated or comma separated)")
 	rootCmd.AddCommand(configCmd)
 	rootCmd.SetOut(os.Stdout)
 }
@@ -107,7 +122,19 @@ func Execute(cfg *config.Config) error {
 
 	SessionRepository = repositories.NewGORMSessionRepository(db)
 	ClientRepository = repositories.NewGORMClientRepository(db)
+	ProjectRepository = repositories.NewGORMProjectRepository(db)
+	InvoiceRepository = repositories.NewGORMInvoiceRepository(db)
+	ExchangeRateRepository = repositories.NewGORMExchangeRateRepository(db)
+	SyncSnapshotRepository = repositories.NewGORMSyncSnapshotRepository(db)
+	Transaction = func(fn func(repos repositories.Repositories) error) error {
+		return repositories.Transaction(db, fn)
+	}
 	Puncher = puncher.NewPuncher(SessionRepository)
+	Puncher.ConcurrentSessions = Config.Settings.ConcurrentSessions
+	Puncher.OverlapPolicy = puncher.OverlapPolicy(Config.Settings.Overlaps)
+	Puncher.Warn = func(err error) {
+		rootCmd.PrintErrf("Warning: %v\n", err)
+	}
 
 	if Config.Settings.DefaultRemote != "" {
 		remote, ok := Config.Remotes[Config.Settings.DefaultRemote]
@@ -119,6 +146,7 @@ func Execute(cfg *config.Config) error {
 		if err != nil {
 			return err
 		}
+		SourceName = Config.Settings.DefaultRemote
 	} else {
 		Source = nil
 	}
@@ -36,7 +36,7 @@ var deleteSessionCmd = &cobra.Command{
 			return err
 		}
 
-		err = SessionRepository.Delete(session, false)
+		err = DeleteSession(session)
 		if err != nil {
 			return err
 		}
@@ -57,4 +57,5 @@ func init() {
 	rootCmd.AddCommand(deleteCmd)
 	deleteCmd.AddCommand(deleteSessionCmd)
 	deleteCmd.AddCommand(deleteClientCmd)
+	deleteSessionCmd.Flags().BoolVar(&forceModify, "force", false, "Delete the session even if it was invoiced or paid")
 }
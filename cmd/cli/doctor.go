@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var overlapsAllClients bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the stored sessions for problems",
+}
+
+var doctorOverlapsCmd = &cobra.Command{
+	Use:   "overlaps",
+	Short: "List sessions that overlap each other",
+	Long: `List pairs of sessions that were running at the same time, along with a
+    suggested fix. Sessions of different clients are only reported when
+    concurrent sessions are disabled, or with --all-clients.`,
+	Example: `punch doctor overlaps
+punch doctor overlaps -c Acme
+punch doctor overlaps --all-clients`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions, err := SessionRepository.GetAllSessionsAllClients()
+		if err != nil {
+			return err
+		}
+		sessions = FilterSessionsByClient(sessions, clientName)
+
+		sameClientOnly := Config.Settings.ConcurrentSessions && !overlapsAllClients
+		pairs := models.FindOverlaps(*sessions, sameClientOnly)
+		if len(pairs) == 0 {
+			cmd.Println("No overlapping sessions")
+			return nil
+		}
+		cmd.Print(generateOverlapsView(pairs))
+		return nil
+	},
+}
+
+func generateOverlapsView(pairs []models.OverlappingPair) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+	if !hideHeaders {
+		fmt.Fprintln(writer, "FIRST\tSECOND\tCLIENTS\tSTART\tOVERLAP\tSUGGESTION")
+	}
+	for _, pair := range pairs {
+		clients := pair.First.Client.Name
+		if pair.Second.Client.Name != clients {
+			clients += ", " + pair.Second.Client.Name
+		}
+		fmt.Fprintf(writer, "%d\t%d\t%s\t%s\t%s\t%s\n",
+			pair.First.ID,
+			pair.Second.ID,
+			clients,
+			pair.Second.Start.Format("2006-01-02 15:04:05"),
+			models.FormatDuration(overlapDuration(pair)),
+			suggestOverlapFix(pair))
+	}
+	writer.Flush()
+	return buffer.String()
+}
+
+// overlapDuration is how long both sessions ran at the same time, open
+// sessions count until now.
+func overlapDuration(pair models.OverlappingPair) time.Duration {
+	end := time.Now()
+	if pair.First.Finished() && pair.First.End.Before(end) {
+		end = pair.First.End
+	}
+	if pair.Second.Finished() && pair.Second.End.Before(end) {
+		end = pair.Second.End
+	}
+	if end.Before(pair.Second.Start) {
+		return 0
+	}
+	return end.Sub(pair.Second.Start)
+}
+
+func suggestOverlapFix(pair models.OverlappingPair) string {
+	first, second := pair.First, pair.Second
+	if first.Similar(second) {
+		return fmt.Sprintf("delete %d, it duplicates %d", second.ID, first.ID)
+	}
+	if first.Finished() && second.Finished() && !second.End.After(first.End) {
+		return fmt.Sprintf("delete %d or split %d around it", second.ID, first.ID)
+	}
+	return fmt.Sprintf("end %d at %s", first.ID, second.Start.Format("15:04:05"))
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.AddCommand(doctorOverlapsCmd)
+	doctorOverlapsCmd.Flags().StringVarP(&clientName, "client", "c", "", "Only check the sessions of a client")
+	doctorOverlapsCmd.Flags().BoolVar(&overlapsAllClients, "all-clients", false, "Report overlaps between different clients as well")
+	doctorOverlapsCmd.Flags().BoolVar(&hideHeaders, "hide-headers", false, "Hide headers in the output")
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/config"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_DoctorOverlaps_ListsPairsWithSuggestions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	previousConfig := Config
+	Config = &config.Config{Settings: config.Settings{ConcurrentSessions: true}}
+	defer func() { Config = previousConfig }()
+
+	first := createSampleSession()
+	second := createSampleSession()
+	second.ID = 2
+	second.Start = first.Start.Add(7 * time.Hour)
+	second.End = first.End.Add(time.Hour)
+	other := createSampleSession()
+	other.ID = 3
+	other.Client = models.Client{Name: "Other Client"}
+	sessions := []models.Session{first, second, other}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsAllClients().
+		Return(&sessions, nil).
+		Times(1)
+
+	content, err := executeCommand(t, []string{"doctor", "overlaps"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, content, "end 1 at 16:00:00")
+	assert.NotContains(t, content, "Other Client")
+}
+
+func TestCli_SuggestOverlapFix(t *testing.T) {
+	first := createSampleSession()
+	duplicate := createSampleSession()
+	duplicate.ID = 2
+	contained := createSampleSession()
+	contained.ID = 3
+	contained.Start = first.Start.Add(time.Hour)
+	contained.End = first.Start.Add(2 * time.Hour)
+
+	assert.Equal(t, "delete 2, it duplicates 1",
+		suggestOverlapFix(models.OverlappingPair{First: first, Second: duplicate}))
+	assert.Equal(t, "delete 3 or split 1 around it",
+		suggestOverlapFix(models.OverlappingPair{First: first, Second: contained}))
+}
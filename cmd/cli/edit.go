@@ -14,7 +14,8 @@ import (
 )
 
 var (
-	approveDelete bool
+	approveDelete     bool
+	rateEffectiveFrom string
 )
 
 var editCmd = &cobra.Command{
@@ -52,9 +53,23 @@ var editClientCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		err = ClientRepository.Update(&updateClient)
-		if err != nil {
-			return err
+		if rateEffectiveFrom != "" && updateClient.PPH != client.PPH {
+			effectiveFrom, err := time.ParseInLocation("2006-01-02", rateEffectiveFrom, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid effective date (format: YYYY-MM-DD): %v", err)
+			}
+			err = ClientRepository.SetRate(&updateClient, models.HourlyRate{
+				EffectiveFrom: effectiveFrom,
+				PPH:           updateClient.PPH,
+			})
+			if err != nil {
+				return err
+			}
+		} else {
+			err = ClientRepository.Update(&updateClient)
+			if err != nil {
+				return err
+			}
 		}
 		rootCmd.Printf("Updated client %s\n", updateClient.Name)
 		return nil
@@ -81,6 +96,7 @@ var editSessionCmd = &cobra.Command{
 		} else {
 			sessions = GetRelativeSessionsFromArgs(args, clientName)
 		}
+		sessions = *FilterSessionsByTags(&sessions, tagFilter)
 
 		SortSessions(&sessions, descendingOrder)
 
@@ -93,11 +109,21 @@ var editSessionCmd = &cobra.Command{
 		for _, session := range editedSessions {
 			for _, previousSession := range sessions {
 				if session.ID == previousSession.ID && !previousSession.Equals(session) {
-					sessionsUpdatedCount++
-					err = SessionRepository.Update(&session, false)
+					session.InvoiceNumber = previousSession.InvoiceNumber
+					session.Status = previousSession.Status
+					if session.Project != nil {
+						session.Project, err = ProjectRepository.GetByName(session.Client, session.Project.Name)
+						if err != nil {
+							rootCmd.Printf("Unable to update session %s: %v\n", session.Start, err)
+							continue
+						}
+					}
+					err = UpdateSession(&session)
 					if err != nil {
 						rootCmd.Printf("Unable to update session %s: %v\n", session.Start, err)
+						continue
 					}
+					sessionsUpdatedCount++
 				}
 			}
 		}
@@ -106,13 +132,16 @@ var editSessionCmd = &cobra.Command{
 
 		deletedSessions := sync.DetectDeletedSessions(&sessions, &editedSessions)
 		if len(deletedSessions) > 0 && verifyDeletion(deletedSessions) {
+			sessionsDeletedCount := 0
 			for _, session := range deletedSessions {
-				err = SessionRepository.Delete(&session, false)
+				err = DeleteSession(&session)
 				if err != nil {
 					rootCmd.Printf("Unable to delete session %s: %v\n", session.String(), err)
+					continue
 				}
+				sessionsDeletedCount++
 			}
-			rootCmd.Printf("Deleted %d session(s)\n", len(deletedSessions))
+			rootCmd.Printf("Deleted %d session(s)\n", sessionsDeletedCount)
 		}
 
 		if slices.Contains(Config.Settings.AutoSync, "edit") {
@@ -165,13 +194,20 @@ func init() {
 	rootCmd.AddCommand(editCmd)
 	editCmd.AddCommand(editSessionCmd)
 	editCmd.AddCommand(editClientCmd)
+	editClientCmd.Flags().StringVar(&rateEffectiveFrom, "effective", "", "Date from which a changed hourly rate takes effect (format: YYYY-MM-DD), defaults to today")
 	editSessionCmd.Flags().StringVarP(&clientName, "client", "c", "", "Specify the client name")
+	editSessionCmd.Flags().StringSliceVarP(&tagFilter, "tag", "t", nil, "Only edit sessions with any of the given tags")
 	editSessionCmd.Flags().BoolVar(&dayReport, "day", false, "Edit report for this current day")
-	editSessionCmd.Flags().BoolVar(&weekReport, "week", false, "Edit report for this current week")
-	editSessionCmd.Flags().StringVar(&monthReport, "month", "", "Edit report for a specific month (format: YYYY-MM), leave empty for current month")
-	editSessionCmd.Flags().StringVar(&yearReport, "year", "", "Edit report for a specific year (format: YYYY), leave empty for current year")
+	editSessionCmd.Flags().StringVar(&weekReport, "week", "", "Edit report for a specific week (format: -N or YYYY-Www), leave empty for current week")
+	editSessionCmd.Flags().StringVar(&monthReport, "month", "", "Edit report for a specific month (format: -N, MM or YYYY-MM), leave empty for current month")
+	editSessionCmd.Flags().StringVar(&yearReport, "year", "", "Edit report for a specific year (format: -N or YYYY), leave empty for current year")
+	editSessionCmd.Flags().BoolVar(&prevReport, "prev", false, "Edit report for the period before the selected day, week, month or year")
 	editSessionCmd.Flags().BoolVarP(&allReport, "all", "a", false, "Edit all clients")
+	editSessionCmd.Flags().StringVar(&fromDate, "from", "", "Edit sessions from a specific date or time (e.g. 2026-09-01)")
+	editSessionCmd.Flags().StringVar(&toDate, "to", "", "Edit sessions up to a specific date or time, a date is included entirely (e.g. 2026-09-15)")
 	editSessionCmd.Flags().BoolVarP(&approveDelete, "yes", "y", false, "Approve deletion of sessions automatically")
+	editSessionCmd.Flags().BoolVar(&forceModify, "force", false, "Modify invoiced or paid sessions")
 	editSessionCmd.Flags().Lookup("month").NoOptDefVal = strconv.Itoa(int(currentMonth))
+	editSessionCmd.Flags().Lookup("week").NoOptDefVal = "0"
 	editSessionCmd.Flags().Lookup("year").NoOptDefVal = strconv.Itoa(currentYear)
 }
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -16,7 +19,10 @@ var (
 	output          string
 	descendingOrder bool
 	summary         bool
+	summaryGroupBy  string
 	hideHeaders     bool
+	showRates       bool
+	openOnly        bool
 
 	ErrNoAvailableData = errors.New("no available data")
 )
@@ -31,21 +37,74 @@ var getClientCmd = &cobra.Command{
 	Short:   "Get a client",
 	Aliases: []string{"clients"},
 	Args:    cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return preRunCheckOutput("text", "json", "ndjson")
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var clients []models.Client
 		if len(args) == 1 {
 			client, err := ClientRepository.GetByName(args[0])
 			if err != nil {
 				return fmt.Errorf("unable to get client: %v", err)
 			}
-			rootCmd.Println(client.String())
+			if showRates {
+				rootCmd.Print(generateRatesView(client))
+				return nil
+			}
+			clients = append(clients, *client)
 		} else {
-			clients, err := ClientRepository.GetAll()
+			var err error
+			clients, err = ClientRepository.GetAll()
 			if err != nil {
 				return fmt.Errorf("unable to get clients: %v", err)
 			}
-			for _, client := range clients {
-				rootCmd.Println(client.String())
+		}
+
+		if isJSONOutput() {
+			buffer, err := models.SerializeClientsToJSON(clients, output == "ndjson")
+			if err != nil {
+				return err
 			}
+			rootCmd.Print(buffer.String())
+			return nil
+		}
+		for _, client := range clients {
+			rootCmd.Println(client.String())
+		}
+		return nil
+	},
+}
+
+var getProjectCmd = &cobra.Command{
+	Use:     "project [name]",
+	Short:   "Get a project",
+	Aliases: []string{"projects"},
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var projects []models.Project
+		var err error
+		if len(args) == 1 || currentClientName != "" {
+			err = GetClientIfExists(currentClientName)
+			if err != nil {
+				return err
+			}
+		}
+		if len(args) == 1 {
+			project, err := ProjectRepository.GetByName(*currentClient, args[0])
+			if err != nil {
+				return fmt.Errorf("unable to get project: %v", err)
+			}
+			projects = append(projects, *project)
+		} else if currentClient != nil {
+			projects, err = ProjectRepository.GetAllByClient(*currentClient)
+		} else {
+			projects, err = ProjectRepository.GetAll()
+		}
+		if err != nil {
+			return fmt.Errorf("unable to get projects: %v", err)
+		}
+		for _, project := range projects {
+			rootCmd.Println(project.String())
 		}
 		return nil
 	},
@@ -58,15 +117,28 @@ var getSessionCmd = &cobra.Command{
     If a date is specified, the format must be YYYY-MM-DD.`,
 	Example: `punch get session
 punch get session 2020-01-01
-punch get session 01-01`,
+punch get session 01-01
+punch get session 2026-09-01..2026-09-15
+punch get session --from 2026-09-01 --to 2026-09-15
+punch get session --open
+punch get session --month --group-by week`,
 	Args:    cobra.MaximumNArgs(1),
 	Aliases: []string{"sessions"},
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		err := preRunCheckOutput()
+		err := preRunCheckOutput("text", "csv", "json", "ndjson")
 		if err != nil {
 			return err
 		}
 
+		if cmd.Flags().Changed("group-by") {
+			groupBy, err := models.ParseSummaryGroupBy(summaryGroupBy)
+			if err != nil {
+				return err
+			}
+			summaryGroupBy = string(groupBy)
+			summary = true
+		}
+
 		reportTimeframe, err = ExtractTimeframeFromFlags()
 		if err != nil {
 			return err
@@ -77,7 +149,9 @@ punch get session 01-01`,
 		var sessions []models.Session
 		var err error
 
-		if len(args) == 1 {
+		if openOnly {
+			sessions, err = Puncher.OpenSessions(nil)
+		} else if len(args) == 1 {
 			sessions = GetRelativeSessionsFromArgs(args, clientName)
 		} else {
 			sessions = GetSessionsWithTimeframe(*reportTimeframe)
@@ -87,6 +161,7 @@ punch get session 01-01`,
 		}
 
 		filteredSessions := FilterSessionsByClient(&sessions, clientName)
+		filteredSessions = FilterSessionsByTags(filteredSessions, tagFilter)
 		SortSessions(filteredSessions, descendingOrder)
 		content, err := generateView(filteredSessions)
 		if err != nil {
@@ -97,8 +172,28 @@ punch get session 01-01`,
 	},
 }
 
+func generateRatesView(client *models.Client) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+	if !hideHeaders {
+		fmt.Fprintln(writer, "EFFECTIVE FROM\tPPH\tCURRENCY")
+	}
+	if len(client.Rates) == 0 {
+		fmt.Fprintf(writer, "initial\t%d\t%s\n", client.PPH, client.Currency)
+	}
+	for _, rate := range client.Rates {
+		effectiveFrom := "initial"
+		if !rate.EffectiveFrom.IsZero() {
+			effectiveFrom = rate.EffectiveFrom.Format("2006-01-02")
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%s\n", effectiveFrom, rate.PPH, client.Currency)
+	}
+	writer.Flush()
+	return buffer.String()
+}
+
 func generateView(slice *[]models.Session) (*string, error) {
-	if len(*slice) == 0 {
+	if len(*slice) == 0 && !isJSONOutput() {
 		return nil, ErrNoAvailableData
 	}
 	var (
@@ -115,12 +210,37 @@ func generateView(slice *[]models.Session) (*string, error) {
 			content, err = generateFullGetView(slice)
 		}
 	case "csv":
-		if verbose {
+		if summary {
+			var summaries []models.SessionSummary
+			summaries, err = summarizeSessions(slice)
+			if err != nil {
+				return nil, err
+			}
+			buffer, err = models.SerializeSummariesToCSV(summaries, models.SummaryGroupBy(summaryGroupBy), reportingCurrency())
+			if err != nil {
+				return nil, err
+			}
+		} else if verbose {
 			buffer, err = models.SerializeSessionsToFullCSV(*slice)
 		} else {
 			buffer, err = models.SerializeSessionsToCSV(*slice)
 		}
 		content = buffer.String()
+	case "json", "ndjson":
+		if summary {
+			var summaries []models.SessionSummary
+			summaries, err = summarizeSessions(slice)
+			if err != nil {
+				return nil, err
+			}
+			jsonSummaries := models.SummariesToJSON(summaries, models.SummaryGroupBy(summaryGroupBy), reportingCurrency())
+			buffer, err = models.SerializeToJSON(jsonSummaries, output == "ndjson")
+		} else {
+			buffer, err = models.SerializeSessionsToJSON(*slice, output == "ndjson")
+		}
+		if err == nil {
+			content = buffer.String()
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -136,96 +256,133 @@ func generateSummaryView(slice *[]models.Session) (string, error) {
 	buffer := new(bytes.Buffer)
 	w := tabwriter.NewWriter(buffer, 0, 0, 1, ' ', tabwriter.TabIndent)
 	if !hideHeaders {
-		_, err := fmt.Fprintln(w, "DATE\tCLIENT\tTIME\tAMOUNT\tCURRENCY")
+		_, err := fmt.Fprintf(w, "%s\tLAST DATE\tTIME\tBILLABLE\tAMOUNT\tCURRENCY\n",
+			strings.ToUpper(summaryGroupBy))
 		if err != nil {
 			return "", err
 		}
 	}
 
-	clientData := make(map[string]struct {
-		totalTime   time.Duration
-		totalAmount float64
-		lastDate    time.Time
-		currency    string
-	})
-	currencyData := make(map[string]struct {
-		totalTime   time.Duration
-		totalAmount float64
-	})
+	summaries, err := summarizeSessions(slice)
+	if err != nil {
+		return "", err
+	}
+	rows := summaries
+	if currency := reportingCurrency(); currency != "" {
+		rows = append(rows, models.SummaryGrandTotal(summaries, currency))
+	} else if len(summaries) > 1 {
+		rows = append(rows, models.SummaryTotals(summaries)...)
+	}
+	for _, row := range rows {
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%.2f\t%s\n",
+			row.Group,
+			row.LastDate.Format("2006-01-02"),
+			row.Duration.Truncate(time.Second),
+			row.Billable.Truncate(time.Second),
+			row.Earnings,
+			row.Currency)
+		if err != nil {
+			return "", err
+		}
+	}
 
+	err = w.Flush()
+	if err != nil {
+		return "", err
+	}
+
+	tagSummary, err := generateTagSummaryView(slice)
+	if err != nil {
+		return "", err
+	}
+	if tagSummary != "" {
+		buffer.WriteString("\n" + tagSummary)
+	}
+	return buffer.String(), nil
+}
+
+// summarizeSessions groups the sessions by --group-by, converting their
+// earnings to the reporting currency when one is configured.
+func summarizeSessions(slice *[]models.Session) ([]models.SessionSummary, error) {
+	rates, err := GetExchangeRates()
+	if err != nil {
+		return nil, err
+	}
+	return models.SummarizeSessions(*slice, models.SummaryGroupBy(summaryGroupBy), weekStart(), rates)
+}
+
+// generateTagSummaryView breaks down the summary per tag and client. A session
+// with several tags is counted once for each of its tags.
+func generateTagSummaryView(slice *[]models.Session) (string, error) {
+	type tagKey struct {
+		tag    string
+		client string
+	}
+	type tagData struct {
+		totalTime    time.Duration
+		billableTime time.Duration
+		totalAmount  float64
+		currency     string
+	}
+
+	tagged := false
+	tagsData := make(map[tagKey]tagData)
 	for _, session := range *slice {
-		client := session.Client.Name
-		currency := session.Client.Currency
-
-		if _, exists := clientData[client]; !exists {
-			clientData[client] = struct {
-				totalTime   time.Duration
-				totalAmount float64
-				lastDate    time.Time
-				currency    string
-			}{currency: currency}
+		tags := session.Tags
+		if len(tags) == 0 {
+			tags = []string{"<untagged>"}
+		} else {
+			tagged = true
 		}
-		if _, exists := currencyData[currency]; !exists {
-			currencyData[currency] = struct {
-				totalTime   time.Duration
-				totalAmount float64
-			}{}
+		for _, tag := range tags {
+			key := tagKey{tag: tag, client: session.Client.Name}
+			data := tagsData[key]
+			data.currency = session.Client.Currency
+			data.totalTime += session.WorkDuration()
+			data.billableTime += session.BillableDuration()
+			earnings, err := session.Earnings()
+			if err == nil {
+				data.totalAmount += earnings
+			}
+			tagsData[key] = data
 		}
+	}
+	if !tagged {
+		return "", nil
+	}
 
-		data := clientData[client]
-		sessionEnd := time.Now()
-		if session.Finished() {
-			sessionEnd = session.End
-		}
-		duration := sessionEnd.Sub(session.Start)
-		data.totalTime += duration.Truncate(time.Second)
-		currencyData[currency] = struct {
-			totalTime   time.Duration
-			totalAmount float64
-		}{
-			totalTime:   currencyData[currency].totalTime + duration,
-			totalAmount: currencyData[currency].totalAmount,
-		}
-		earnings, err := session.Earnings()
-		if err == nil {
-			data.totalAmount += earnings
-			currencyData[currency] = struct {
-				totalTime   time.Duration
-				totalAmount float64
-			}{
-				totalTime:   currencyData[currency].totalTime,
-				totalAmount: currencyData[currency].totalAmount + earnings,
-			}
+	keys := make([]tagKey, 0, len(tagsData))
+	for key := range tagsData {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].tag != keys[j].tag {
+			return keys[i].tag < keys[j].tag
 		}
-		if session.Start.After(data.lastDate) {
-			data.lastDate = session.Start
+		return keys[i].client < keys[j].client
+	})
+
+	buffer := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buffer, 0, 0, 1, ' ', tabwriter.TabIndent)
+	if !hideHeaders {
+		_, err := fmt.Fprintln(w, "TAG\tCLIENT\tTIME\tBILLABLE\tAMOUNT\tCURRENCY")
+		if err != nil {
+			return "", err
 		}
-		clientData[client] = data
 	}
-
-	for client, data := range clientData {
-		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%.2f\t%s\n",
-			data.lastDate.Format("2006-01-02"),
-			client,
-			data.totalTime,
+	for _, key := range keys {
+		data := tagsData[key]
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%.2f\t%s\n",
+			key.tag,
+			key.client,
+			data.totalTime.Truncate(time.Second),
+			data.billableTime.Truncate(time.Second),
 			data.totalAmount,
 			data.currency)
 		if err != nil {
 			return "", err
 		}
 	}
-	if len(clientData) > 1 {
-		for currency, data := range currencyData {
-			_, err := fmt.Fprintf(w, "-\t<all>\t%s\t%.2f\t%s\n",
-				data.totalTime,
-				data.totalAmount,
-				currency)
-			if err != nil {
-				return "", err
-			}
-		}
-	}
-
 	err := w.Flush()
 	if err != nil {
 		return "", err
@@ -238,7 +395,7 @@ func generateFullGetView(slice *[]models.Session) (string, error) {
 	w := tabwriter.NewWriter(buffer, 0, 0, 1, ' ', tabwriter.TabIndent)
 	if !hideHeaders {
 		if verbose {
-			_, err := fmt.Fprintln(w, "ID\tDATE\tCLIENT\tSTART\tEND\tDURATION\tAMOUNT\tCURRENCY\tNOTE")
+			_, err := fmt.Fprintln(w, "ID\tDATE\tCLIENT\tPROJECT\tSTART\tEND\tDURATION\tBILLABLE\tBREAKS\tAMOUNT\tCURRENCY\tSTATUS\tTAGS\tNOTE")
 			if err != nil {
 				return "", err
 			}
@@ -259,15 +416,20 @@ func generateFullGetView(slice *[]models.Session) (string, error) {
 		}
 
 		if verbose {
-			_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%.2f\t%s\t%s\n",
+			_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%.2f\t%s\t%s\t%s\t%s\n",
 				id,
 				session.Start.Format("2006-01-02"),
 				session.Client.Name,
+				session.ProjectName(),
 				session.Start.Format("15:04:05"),
 				endTime,
 				session.Duration(),
+				models.FormatDuration(session.BillableDuration()),
+				models.FormatDuration(session.BreakDuration()),
 				earnings,
 				session.Client.Currency,
+				session.Status,
+				strings.Join(session.Tags, ","),
 				session.Note,
 			)
 			if err != nil {
@@ -293,13 +455,17 @@ func generateFullGetView(slice *[]models.Session) (string, error) {
 	return buffer.String(), nil
 }
 
-func preRunCheckOutput() error {
-	allowedOutputs := map[string]bool{"csv": true, "text": true}
-	if _, ok := allowedOutputs[output]; !ok {
-		return fmt.Errorf("invalid output format: %s, allowed formats are 'csv' and 'text'", output)
+func preRunCheckOutput(allowedOutputs ...string) error {
+	if !slices.Contains(allowedOutputs, output) {
+		return fmt.Errorf("invalid output format: %s, allowed formats are '%s'",
+			output,
+			strings.Join(allowedOutputs, "', '"))
 	}
 	return nil
+}
 
+func isJSONOutput() bool {
+	return output == "json" || output == "ndjson"
 }
 
 func init() {
@@ -307,17 +473,28 @@ func init() {
 	rootCmd.AddCommand(getCmd)
 	getCmd.AddCommand(getSessionCmd)
 	getCmd.AddCommand(getClientCmd)
+	getClientCmd.Flags().BoolVar(&showRates, "rates", false, "Show the client's hourly rate history")
+	getClientCmd.Flags().StringVarP(&output, "output", "o", "text", "Specify the output format (text, json or ndjson)")
+	getCmd.AddCommand(getProjectCmd)
+	getProjectCmd.Flags().StringVarP(&currentClientName, "client", "c", "", "Specify the client name")
 	getSessionCmd.Flags().StringVarP(&clientName, "client", "c", "", "Specify the client name")
+	getSessionCmd.Flags().StringSliceVarP(&tagFilter, "tag", "t", nil, "Only get sessions with any of the given tags")
 	getSessionCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
 	getSessionCmd.Flags().BoolVarP(&summary, "summary", "s", false, "Output summary of sessions")
+	getSessionCmd.Flags().StringVar(&summaryGroupBy, "group-by", string(models.SUMMARY_GROUP_BY_CLIENT), "Group the summary by client, day, week, month, note or currency (implies --summary)")
 	getSessionCmd.Flags().BoolVar(&hideHeaders, "hide-headers", false, "Output summary of sessions")
 	getSessionCmd.Flags().BoolVar(&dayReport, "day", false, "Hide headers in report")
-	getSessionCmd.Flags().BoolVar(&weekReport, "week", false, "Get report for this current week")
-	getSessionCmd.Flags().StringVar(&monthReport, "month", "", "Get report for a specific month (format: YYYY-MM), leave empty for current month")
-	getSessionCmd.Flags().StringVar(&yearReport, "year", "", "Get report for a specific year (format: YYYY), leave empty for current year")
+	getSessionCmd.Flags().StringVar(&weekReport, "week", "", "Get report for a specific week (format: -N or YYYY-Www), leave empty for current week")
+	getSessionCmd.Flags().StringVar(&monthReport, "month", "", "Get report for a specific month (format: -N, MM or YYYY-MM), leave empty for current month")
+	getSessionCmd.Flags().StringVar(&yearReport, "year", "", "Get report for a specific year (format: -N or YYYY), leave empty for current year")
+	getSessionCmd.Flags().BoolVar(&prevReport, "prev", false, "Get report for the period before the selected day, week, month or year")
 	getSessionCmd.Flags().BoolVar(&allReport, "all", false, "Get all sessions")
+	getSessionCmd.Flags().StringVar(&fromDate, "from", "", "Get sessions from a specific date or time (e.g. 2026-09-01)")
+	getSessionCmd.Flags().StringVar(&toDate, "to", "", "Get sessions up to a specific date or time, a date is included entirely (e.g. 2026-09-15)")
+	getSessionCmd.Flags().BoolVar(&openOnly, "open", false, "Get the sessions that are still open")
 	getSessionCmd.Flags().BoolVar(&descendingOrder, "desc", false, "Sort sessions in descending order (defaults to ascending order)")
-	getSessionCmd.Flags().StringVarP(&output, "output", "o", "text", "Specify the output format")
+	getSessionCmd.Flags().StringVarP(&output, "output", "o", "text", "Specify the output format (text, csv, json or ndjson)")
 	getSessionCmd.Flags().Lookup("month").NoOptDefVal = strconv.Itoa(int(currentMonth))
+	getSessionCmd.Flags().Lookup("week").NoOptDefVal = "0"
 	getSessionCmd.Flags().Lookup("year").NoOptDefVal = strconv.Itoa(currentYear)
 }
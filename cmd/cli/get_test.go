@@ -2,9 +2,11 @@ package cli
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/dormunis/punch/pkg/config"
 	"github.com/dormunis/punch/pkg/models"
 	"github.com/dormunis/punch/pkg/repositories"
 	"github.com/golang/mock/gomock"
@@ -100,6 +102,127 @@ func TestCli_GetSession_DayFlagQueriesForDayOnly(t *testing.T) {
 	assert.ErrorIs(t, err, ErrNoAvailableData)
 }
 
+func TestCli_GetSession_FromToFlagsQueryForRange(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	dayReport = false
+	defer func() { fromDate, toDate = "", "" }()
+
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, time.September, 16, 0, 0, 0, 0, time.Local)
+	returnVal := []models.Session{}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsBetweenDates(from, to).
+		Return(&returnVal, nil).
+		Times(1)
+
+	args := []string{"get", "session", "--from", "2026-09-01", "--to", "2026-09-15"}
+	_, err := executeCommand(t, args)
+	assert.ErrorIs(t, err, ErrNoAvailableData)
+}
+
+func TestCli_GetSession_FromConflictsWithOtherTimeframes(t *testing.T) {
+	dayReport = false
+	defer func() { fromDate, weekReport = "", "" }()
+
+	args := []string{"get", "session", "--week", "--from", "2026-09-01"}
+	_, err := executeCommand(t, args)
+	assert.ErrorContains(t, err, "only one of")
+}
+
+func TestCli_GetSession_PrevWeekQueriesForPreviousWeek(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	dayReport = false
+	defer func() { weekReport, prevReport = "", false }()
+
+	today := time.Now()
+	startOfWeek := time.Date(today.Year(), today.Month(), today.Day()-int(today.Weekday())-7, 0, 0, 0, 0, today.Location())
+	returnVal := []models.Session{}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsBetweenDates(startOfWeek, startOfWeek.AddDate(0, 0, 7)).
+		Return(&returnVal, nil).
+		Times(1)
+
+	args := []string{"get", "session", "--week", "--prev"}
+	_, err := executeCommand(t, args)
+	assert.ErrorIs(t, err, ErrNoAvailableData)
+}
+
+func TestCli_ParseWeek(t *testing.T) {
+	defer func(previous *config.Config) { Config = previous }(Config)
+	now := time.Date(2026, time.October, 17, 15, 0, 0, 0, time.UTC) // a Saturday
+
+	tests := []struct {
+		value     string
+		weekStart string
+		expected  time.Time
+	}{
+		{"0", "", time.Date(2026, time.October, 11, 0, 0, 0, 0, time.UTC)},
+		{"-1", "", time.Date(2026, time.October, 4, 0, 0, 0, 0, time.UTC)},
+		{"0", "monday", time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)},
+		{"-2", "monday", time.Date(2026, time.September, 28, 0, 0, 0, 0, time.UTC)},
+		{"0", "saturday", time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)},
+		{"2026-W40", "", time.Date(2026, time.September, 28, 0, 0, 0, 0, time.UTC)},
+		{"2026-w01", "", time.Date(2025, time.December, 29, 0, 0, 0, 0, time.UTC)},
+		{"2026-W53", "", time.Date(2026, time.December, 28, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value+" "+test.weekStart, func(t *testing.T) {
+			Config = &config.Config{Settings: config.Settings{WeekStart: test.weekStart}}
+			startOfWeek, err := parseWeek(test.value, now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, startOfWeek)
+		})
+	}
+}
+
+func TestCli_ParseWeek_Invalid(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 15, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{"1", "W40", "2026-W00", "2025-W53", "2026-W5"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := parseWeek(value, now)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCli_ParseMonthAndYear(t *testing.T) {
+	now := time.Date(2026, time.January, 17, 15, 0, 0, 0, time.UTC)
+
+	month, err := parseMonth("-1", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC), month)
+
+	month, err = parseMonth("9", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), month)
+
+	month, err = parseMonth("2025-09", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC), month)
+
+	_, err = parseMonth("13", now)
+	assert.Error(t, err)
+
+	year, err := parseYear("-1", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), year)
+
+	year, err = parseYear("2024", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), year)
+
+	_, err = parseYear("2027", now)
+	assert.Error(t, err)
+}
+
 // func TestCli_GetSession_WeekFlagQueriesForWeekOnly(t *testing.T) {
 // 	mockCtrl := gomock.NewController(t)
 // 	defer mockCtrl.Finish()
@@ -156,3 +279,118 @@ func TestCli_GetSession_DayFlagQueriesForDayOnly(t *testing.T) {
 // 	_, err := executeCommand(t, args)
 // 	assert.ErrorIs(t, err, NoAvailableDataError)
 // }
+
+func TestCli_FilterSessionsByTags_KeepsSessionsWithAnyTag(t *testing.T) {
+	dev := createSampleSession()
+	dev.Tags = []string{"dev"}
+	meetings := createSampleSession()
+	meetings.Tags = []string{"meetings", "support"}
+	untagged := createSampleSession()
+
+	sessions := []models.Session{dev, meetings, untagged}
+	filtered := FilterSessionsByTags(&sessions, []string{"support", "DEV"})
+
+	assert.Len(t, *filtered, 2)
+}
+
+func TestCli_GenerateSummaryView_IncludesTagBreakdown(t *testing.T) {
+	session := createSampleSession()
+	session.Tags = []string{"dev"}
+	sessions := []models.Session{session}
+
+	content, err := generateSummaryView(&sessions)
+
+	assert.NoError(t, err)
+	assert.Contains(t, content, "TAG")
+	assert.Contains(t, content, "dev")
+}
+
+func TestCli_GenerateView_JSONHonoursSummary(t *testing.T) {
+	output, summary = "ndjson", true
+	defer func() { output, summary = "text", false }()
+	sessions := []models.Session{createSampleSession(), createSampleSession()}
+
+	content, err := generateView(&sessions)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"group_by":"client","group":"Test Client","client":"Test Client","last_date":"`+sessions[0].Start.Format("2006-01-02")+
+		`","duration_seconds":57600,"billable_seconds":57600,"earnings":673104,"currency":"USD"}`+"\n", *content)
+}
+
+func TestCli_GenerateSummaryView_GroupByDayIsSortedWithTotals(t *testing.T) {
+	summaryGroupBy = "day"
+	defer func() { summaryGroupBy = "client" }()
+	first := createSampleSession()
+	second := createSampleSession()
+	second.Start = first.Start.AddDate(0, 0, -1)
+	second.End = first.End.AddDate(0, 0, -1)
+	euro := createSampleSession()
+	euro.Client = models.Client{Name: "Euro Client", Currency: "EUR", PPH: 100}
+	sessions := []models.Session{first, euro, second}
+
+	for i := 0; i < 5; i++ {
+		content, err := generateSummaryView(&sessions)
+
+		assert.NoError(t, err)
+		lines := strings.Split(content, "\n")
+		assert.True(t, strings.HasPrefix(lines[0], "DAY"))
+		assert.True(t, strings.HasPrefix(lines[1], second.Start.Format("2006-01-02")))
+		assert.Contains(t, lines[2], "EUR")
+		assert.Contains(t, lines[3], "USD")
+		assert.True(t, strings.HasPrefix(lines[4], "<all>"))
+		assert.Contains(t, lines[4], "EUR")
+		assert.Contains(t, lines[5], "16h0m0s")
+		assert.Contains(t, lines[5], "USD")
+	}
+}
+
+func TestCli_GetSession_GroupByImpliesSummary(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	dayReport = false
+	defer func() {
+		summary, summaryGroupBy, output = false, "client", "text"
+		fromDate, toDate = "", ""
+		getSessionCmd.Flags().Lookup("group-by").Changed = false
+	}()
+
+	session := createSampleSession()
+	session.Start = time.Date(2026, time.September, 14, 9, 0, 0, 0, time.Local)
+	session.End = session.Start.Add(8 * time.Hour)
+	sessions := []models.Session{session}
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsBetweenDates(gomock.Any(), gomock.Any()).
+		Return(&sessions, nil).
+		Times(1)
+
+	content, err := executeCommand(t, []string{"get", "session",
+		"--from", "2026-09-01", "--to", "2026-09-30", "--group-by", "month", "-o", "csv"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "month,last_date,duration,billable,amount,currency\n"+
+		"2026-09,2026-09-14,08:00:00,08:00:00,336552.00,USD\n", content)
+}
+
+func TestCli_GetSession_InvalidGroupBy(t *testing.T) {
+	dayReport = false
+	defer func() {
+		summary, summaryGroupBy = false, "client"
+		getSessionCmd.Flags().Lookup("group-by").Changed = false
+	}()
+
+	_, err := executeCommand(t, []string{"get", "session", "--group-by", "tag"})
+
+	assert.ErrorIs(t, err, models.ErrInvalidSummaryGroupBy)
+}
+
+func TestCli_GenerateView_JSONWithoutSessionsIsEmptyArray(t *testing.T) {
+	output = "json"
+	defer func() { output = "text" }()
+	sessions := []models.Session{}
+
+	content, err := generateView(&sessions)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[]\n", *content)
+}
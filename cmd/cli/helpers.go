@@ -3,14 +3,19 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
 	"github.com/spf13/viper"
 )
 
+var isoWeekPattern = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+
 type ReportTimeframe string
 
 const (
@@ -18,16 +23,24 @@ const (
 	REPORT_TIMEFRAME_WEEK  ReportTimeframe = "week"
 	REPORT_TIMEFRAME_MONTH ReportTimeframe = "month"
 	REPORT_TIMEFRAME_YEAR  ReportTimeframe = "year"
+	REPORT_TIMEFRAME_RANGE ReportTimeframe = "range"
 )
 
 var (
 	clientName      string
+	tagFilter       []string
 	dayReport       bool
-	weekReport      bool
+	weekReport      string
 	monthReport     string
 	yearReport      string
 	reportTimeframe *ReportTimeframe
 	allReport       bool
+	prevReport      bool
+	fromDate        string
+	toDate          string
+	rangeStart      time.Time
+	rangeEnd        time.Time
+	forceModify     bool
 )
 
 func GetSessionsWithTimeframe(timeframe ReportTimeframe) []models.Session {
@@ -92,6 +105,39 @@ func GetSessionByID(id string) (*models.Session, error) {
 	return session, nil
 }
 
+// UpdateSession saves the session, invoiced and paid sessions and sessions
+// overlapping others are only modified when --force is given.
+func UpdateSession(session *models.Session) error {
+	if forceModify {
+		return SessionRepository.ForceUpdate(session, false)
+	}
+	err := Puncher.CheckOverlaps(*session)
+	if err != nil {
+		return fmt.Errorf("%w, use --force to save it anyway", err)
+	}
+	err = SessionRepository.Update(session, false)
+	return lockedSessionError(err)
+}
+
+// DeleteSession removes the session, invoiced and paid sessions are only
+// removed when --force is given.
+func DeleteSession(session *models.Session) error {
+	var err error
+	if forceModify {
+		err = SessionRepository.ForceDelete(session, false)
+	} else {
+		err = SessionRepository.Delete(session, false)
+	}
+	return lockedSessionError(err)
+}
+
+func lockedSessionError(err error) error {
+	if errors.Is(err, repositories.ErrSessionLocked) {
+		return fmt.Errorf("%v, use --force to modify it anyway", err)
+	}
+	return err
+}
+
 func FilterSessionsByClient(sessions *[]models.Session, clientName string) *[]models.Session {
 	if clientName == "" {
 		return sessions
@@ -105,6 +151,34 @@ func FilterSessionsByClient(sessions *[]models.Session, clientName string) *[]mo
 	return &filteredSessions
 }
 
+// FilterSessionsByRange keeps sessions that started within [start, end).
+func FilterSessionsByRange(sessions *[]models.Session, start time.Time, end time.Time) *[]models.Session {
+	var filteredSessions []models.Session
+	for _, session := range *sessions {
+		if !session.Start.Before(start) && session.Start.Before(end) {
+			filteredSessions = append(filteredSessions, session)
+		}
+	}
+	return &filteredSessions
+}
+
+// FilterSessionsByTags keeps sessions that have at least one of the given tags.
+func FilterSessionsByTags(sessions *[]models.Session, tags []string) *[]models.Session {
+	if len(tags) == 0 {
+		return sessions
+	}
+	var filteredSessions []models.Session
+	for _, session := range *sessions {
+		for _, tag := range tags {
+			if session.HasTag(tag) {
+				filteredSessions = append(filteredSessions, session)
+				break
+			}
+		}
+	}
+	return &filteredSessions
+}
+
 func SortSessions(slice *[]models.Session, descending bool) {
 	sort.SliceStable(*slice, func(i, j int) bool {
 		prevSession := (*slice)[i]
@@ -120,24 +194,33 @@ func SortSessions(slice *[]models.Session, descending bool) {
 func ExtractTimeframeFromFlags() (*ReportTimeframe, error) {
 	timeFlagCount := getAmountOfTimeFilterFlags()
 	if timeFlagCount > 1 {
-		return nil, errors.New("only one of --day, --week, --month, --year or --all can be set")
+		return nil, errors.New("only one of --day, --week, --month, --year, --all or --from/--to can be set")
+	}
+
+	if prevReport && (allReport || fromDate != "" || toDate != "") {
+		return nil, errors.New("--prev can only be used with --day, --week, --month or --year")
 	}
 
 	reportTimeframe := REPORT_TIMEFRAME_DAY
 	if dayReport {
 		reportTimeframe = REPORT_TIMEFRAME_DAY
-	} else if weekReport {
+	} else if weekReport != "" {
 		reportTimeframe = REPORT_TIMEFRAME_WEEK
 	} else if monthReport != "" {
-		if err := validateMonth(monthReport); err != nil {
-			return nil, err
-		}
 		reportTimeframe = REPORT_TIMEFRAME_MONTH
 	} else if yearReport != "" {
-		if err := validateYear(yearReport); err != nil {
+		reportTimeframe = REPORT_TIMEFRAME_YEAR
+	} else if fromDate != "" || toDate != "" {
+		var err error
+		rangeStart, rangeEnd, err = ParseDateRange(fromDate, toDate, nil)
+		if err != nil {
 			return nil, err
 		}
-		reportTimeframe = REPORT_TIMEFRAME_YEAR
+		reportTimeframe = REPORT_TIMEFRAME_RANGE
+		return &reportTimeframe, nil
+	}
+	if _, err := periodStart(reportTimeframe, time.Now()); err != nil {
+		return nil, err
 	}
 	return &reportTimeframe, nil
 }
@@ -185,91 +268,175 @@ func GetClientIfExists(name string) error {
 	return nil
 }
 
+func GetProjectIfExists(name string) error {
+	currentProject = nil
+	if name == "" {
+		return nil
+	}
+	var err error
+	currentProject, err = ProjectRepository.GetByName(*currentClient, name)
+	if err == repositories.ErrProjectNotFound {
+		return fmt.Errorf("project `%s` does not exist for client `%s`", name, currentClient.Name)
+	}
+	return err
+}
+
 func isWithinTimeframe(date time.Time, startDate time.Time, endDate time.Time) bool {
 	return date.After(startDate) && date.Before(endDate)
 }
 
 func getStartDate(timeframe ReportTimeframe) time.Time {
-	today := time.Now()
-	year, _, _ := today.Date()
-
 	if allReport {
 		return time.Time{}
 	}
+	if timeframe == REPORT_TIMEFRAME_RANGE {
+		return rangeStart
+	}
+	startDate, err := periodStart(timeframe, time.Now())
+	if err != nil {
+		// default to current day
+		return startOfDay(time.Now())
+	}
+	return startDate
+}
+
+func getEndDate(timeframe ReportTimeframe, startDate time.Time) time.Time {
+	if allReport {
+		return time.Now()
+	}
+	if timeframe == REPORT_TIMEFRAME_RANGE {
+		return rangeEnd
+	}
+	return shiftPeriod(timeframe, startDate, 1)
+}
 
+// periodStart returns the start of the day, week, month or year selected by
+// the timeframe flags, moved back a period when --prev is given.
+func periodStart(timeframe ReportTimeframe, now time.Time) (time.Time, error) {
+	var startDate time.Time
+	var err error
 	switch timeframe {
-	case REPORT_TIMEFRAME_DAY:
-		return time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
 	case REPORT_TIMEFRAME_WEEK:
-		daysToSubtract := int(today.Weekday())
-		return time.Date(today.Year(), today.Month(), today.Day()-daysToSubtract, 0, 0, 0, 0, today.Location())
+		startDate, err = parseWeek(weekReport, now)
 	case REPORT_TIMEFRAME_MONTH:
-		mo, err := parseMonth(monthReport)
-		if err == nil {
-			return time.Date(year, mo, 1, 0, 0, 0, 0, today.Location())
-		}
+		startDate, err = parseMonth(monthReport, now)
 	case REPORT_TIMEFRAME_YEAR:
-		yr, err := parseYear(yearReport)
-		if err == nil {
-			return time.Date(yr, time.January, 1, 0, 0, 0, 0, today.Location())
-		}
+		startDate, err = parseYear(yearReport, now)
+	default:
+		startDate = startOfDay(now)
 	}
-	// default to current day
-	return today.AddDate(0, 0, -today.Day()+1)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if prevReport {
+		startDate = shiftPeriod(timeframe, startDate, -1)
+	}
+	return startDate, nil
 }
 
-func getEndDate(timeframe ReportTimeframe, startDate time.Time) time.Time {
-	year, _, _ := startDate.Date()
-
+// shiftPeriod moves the start of a period by the given amount of periods.
+func shiftPeriod(timeframe ReportTimeframe, startDate time.Time, periods int) time.Time {
 	switch timeframe {
+	case REPORT_TIMEFRAME_WEEK:
+		return startDate.AddDate(0, 0, 7*periods)
+	case REPORT_TIMEFRAME_MONTH:
+		return startDate.AddDate(0, periods, 0)
 	case REPORT_TIMEFRAME_YEAR:
-		yr, err := parseYear(yearReport)
-		if err == nil {
-			return time.Date(yr, time.December, 31, 0, 0, 0, 0, startDate.Location())
-		}
+		return startDate.AddDate(periods, 0, 0)
+	}
+	return startDate.AddDate(0, 0, periods)
+}
 
-	case REPORT_TIMEFRAME_MONTH:
-		mo, err := parseMonth(monthReport)
-		if err == nil {
-			lastDay := lastDayOfMonth(year, mo)
-			return time.Date(year, mo, lastDay, 0, 0, 0, 0, startDate.Location()).Add(24 * time.Hour)
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// weekStart returns the configured first day of the week, Sunday by default.
+func weekStart() time.Weekday {
+	if Config != nil {
+		if weekday, ok := weekdays[strings.ToLower(Config.Settings.WeekStart)]; ok {
+			return weekday
 		}
 	}
+	return time.Sunday
+}
 
-	// default to now
-	return time.Now()
+// parsePeriodOffset parses an offset from the current period, `0` being the
+// current period and `-1` the previous one.
+func parsePeriodOffset(value string) (int, bool) {
+	if value == "" {
+		return 0, true
+	}
+	offset, err := strconv.Atoi(value)
+	return offset, err == nil && offset <= 0
 }
 
-func parseMonth(monthStr string) (time.Month, error) {
-	monthInt, err := strconv.Atoi(monthStr)
-	if err != nil {
-		return 0, err
+// parseWeek returns the start of the week given either as an offset from the
+// current week or as an ISO week (YYYY-Www), which always starts on Monday.
+func parseWeek(value string, now time.Time) (time.Time, error) {
+	if offset, ok := parsePeriodOffset(value); ok {
+		startOfWeek := previousWeekday(startOfDay(now), weekStart())
+		return startOfWeek.AddDate(0, 0, 7*offset), nil
+	}
+	match := isoWeekPattern.FindStringSubmatch(strings.ToUpper(value))
+	if match == nil {
+		return time.Time{}, errors.New("invalid week format (format: -N or YYYY-Www)")
 	}
-	if monthInt < 1 || monthInt > 12 {
-		return 0, fmt.Errorf("invalid month")
+	year, _ := strconv.Atoi(match[1])
+	week, _ := strconv.Atoi(match[2])
+	return isoWeekStart(year, week, now.Location())
+}
+
+// isoWeekStart returns the Monday starting an ISO 8601 week, the first week
+// of a year being the one containing January 4th.
+func isoWeekStart(year int, week int, location *time.Location) (time.Time, error) {
+	january4 := time.Date(year, time.January, 4, 0, 0, 0, 0, location)
+	startOfWeek := previousWeekday(january4, time.Monday).AddDate(0, 0, 7*(week-1))
+	isoYear, isoWeek := startOfWeek.ISOWeek()
+	if isoYear != year || isoWeek != week {
+		return time.Time{}, fmt.Errorf("invalid week, %d has no week %d", year, week)
 	}
-	return time.Month(monthInt), nil
+	return startOfWeek, nil
 }
 
-func parseYear(yearStr string) (int, error) {
-	return strconv.Atoi(yearStr)
+// parseMonth returns the start of the month given either as an offset from
+// the current month, a month of the current year (MM) or as YYYY-MM.
+func parseMonth(value string, now time.Time) (time.Time, error) {
+	if offset, ok := parsePeriodOffset(value); ok {
+		return time.Date(now.Year(), now.Month()+time.Month(offset), 1, 0, 0, 0, 0, now.Location()), nil
+	}
+	monthInt, err := strconv.Atoi(value)
+	if err == nil && monthInt >= 1 && monthInt <= 12 {
+		return time.Date(now.Year(), time.Month(monthInt), 1, 0, 0, 0, 0, now.Location()), nil
+	}
+	startOfMonth, err := time.ParseInLocation("2006-01", value, now.Location())
+	if err != nil {
+		return time.Time{}, errors.New("invalid month format (format: -N, MM or YYYY-MM)")
+	}
+	return startOfMonth, nil
 }
 
-func lastDayOfMonth(year int, month time.Month) int {
-	if month > 12 {
-		year += 1
+// parseYear returns the start of the year given either as an offset from the
+// current year or as YYYY.
+func parseYear(value string, now time.Time) (time.Time, error) {
+	if offset, ok := parsePeriodOffset(value); ok {
+		return time.Date(now.Year()+offset, time.January, 1, 0, 0, 0, 0, now.Location()), nil
+	}
+	yearInt, err := strconv.Atoi(value)
+	if err != nil || yearInt < 1970 || yearInt > now.Year() {
+		return time.Time{}, errors.New("invalid year format (format: -N or YYYY)")
 	}
-	month = month%12 + 1
-	return time.Date(year, month, 0, 0, 0, 0, 0, time.UTC).Day()
+	return time.Date(yearInt, time.January, 1, 0, 0, 0, 0, now.Location()), nil
 }
 
 func getAmountOfTimeFilterFlags() int8 {
 	flags := []bool{
 		dayReport,
-		weekReport,
+		weekReport != "",
 		monthReport != "",
 		yearReport != "",
-		allReport}
+		allReport,
+		fromDate != "" || toDate != ""}
 	setFlags := 0
 	for _, flag := range flags {
 		if flag {
@@ -279,26 +446,3 @@ func getAmountOfTimeFilterFlags() int8 {
 
 	return int8(setFlags)
 }
-
-func validateMonth(month string) error {
-	monthInt, err := strconv.Atoi(month)
-	if err != nil {
-		return errors.New("invalid month format")
-	}
-	if monthInt < 1 || monthInt > 12 {
-		return errors.New("invalid month format")
-	}
-	return nil
-}
-
-func validateYear(year string) error {
-	yearInt, err := strconv.Atoi(year)
-	if err != nil {
-		return errors.New("invalid year format")
-	}
-	currentYear := time.Now().Year()
-	if yearInt < 1970 || yearInt > currentYear {
-		return errors.New("invalid year format")
-	}
-	return nil
-}
@@ -0,0 +1,379 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dormunis/punch/pkg/importer"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importDryRun   bool
+	importNewIDs   bool
+	importDefaults bool
+	importColumns  map[string]string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [type]",
+	Short: "import sessions from a file",
+}
+
+var importCSVCmd = &cobra.Command{
+	Use:   "csv [file]",
+	Short: "import sessions from a CSV file",
+	Long: `Import sessions from a CSV file, by default in the layout of
+    'punch get session -o csv -v'. Other layouts can be mapped in the
+    [import.csv.columns] section of the config or with --column.`,
+	Example: `punch import csv sessions.csv
+punch import csv sessions.csv --dry-run
+punch import csv export.csv --column client=Customer --column note=Description`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		columns, err := csvImportColumns()
+		if err != nil {
+			return err
+		}
+		csvImporter := importer.NewCSVImporter(columns,
+			Config.Import.CSV.DateFormat,
+			Config.Import.CSV.TimeFormat)
+		return importSessions(csvImporter, args[0])
+	},
+}
+
+var importTogglCmd = &cobra.Command{
+	Use:   "toggl [file]",
+	Short: "import sessions from a Toggl Track detailed CSV report",
+	Long: `Import sessions from a Toggl Track detailed CSV report. Entries are
+    billed to their client (or to their project when they have no client)
+    and their description becomes the session's note.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importSessions(importer.NewTogglImporter(), args[0])
+	},
+}
+
+var importClockifyCmd = &cobra.Command{
+	Use:   "clockify [file]",
+	Short: "import sessions from a Clockify detailed CSV report",
+	Long: `Import sessions from a Clockify detailed CSV report. Entries are
+    billed to their client (or to their project when they have no client)
+    and their description becomes the session's note.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importSessions(importer.NewClockifyImporter(), args[0])
+	},
+}
+
+var importTimewarriorCmd = &cobra.Command{
+	Use:   "timewarrior [file]",
+	Short: "import sessions from the output of 'timew export'",
+	Long: `Import sessions from the JSON output of 'timew export'. The first tag
+    of an interval is its client, and its annotation becomes the session's
+    note. Use '-' to read from the standard input.`,
+	Example: `timew export | punch import timewarrior -`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importSessions(importer.NewTimewarriorImporter(), args[0])
+	},
+}
+
+// csvImportColumns merges the configured and flag column mappings over the
+// default layout.
+func csvImportColumns() (importer.CSVColumns, error) {
+	columns := importer.DefaultCSVColumns()
+	configured := Config.Import.CSV.Columns
+	type columnField struct {
+		name       string
+		configured string
+		column     *string
+	}
+	fields := []columnField{
+		{"id", configured.ID, &columns.ID},
+		{"date", configured.Date, &columns.Date},
+		{"client", configured.Client, &columns.Client},
+		{"project", configured.Project, &columns.Project},
+		{"start_time", configured.StartTime, &columns.StartTime},
+		{"end_time", configured.EndTime, &columns.EndTime},
+		{"breaks", configured.Breaks, &columns.Breaks},
+		{"status", configured.Status, &columns.Status},
+		{"tags", configured.Tags, &columns.Tags},
+		{"note", configured.Note, &columns.Note},
+	}
+	for _, field := range fields {
+		if field.configured != "" {
+			*field.column = field.configured
+		}
+	}
+	for name, header := range importColumns {
+		i := slices.IndexFunc(fields, func(field columnField) bool {
+			return field.name == strings.ToLower(name)
+		})
+		if i == -1 {
+			return columns, fmt.Errorf("unknown column field `%s`", name)
+		}
+		*fields[i].column = header
+	}
+	return columns, nil
+}
+
+func importSessions(sessionImporter importer.Importer, path string) error {
+	file := os.Stdin
+	if path != "-" {
+		var err error
+		file, err = os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+	}
+
+	records, err := sessionImporter.Parse(file)
+	if err != nil {
+		return fmt.Errorf("invalid %s file, nothing was imported:\n%v", sessionImporter.Type(), err)
+	}
+
+	imported, err := resolveImportedRecords(records)
+	if err != nil {
+		return fmt.Errorf("invalid %s file, nothing was imported:\n%v", sessionImporter.Type(), err)
+	}
+	for _, duplicate := range imported.Duplicates {
+		rootCmd.Printf("Skipping duplicate session (row %d): %s\n", duplicate.Row, duplicate.Session.String())
+	}
+
+	err = Transaction(func(repos repositories.Repositories) error {
+		if !importDryRun {
+			for _, client := range imported.Clients {
+				err := repos.Client.Insert(client)
+				if err != nil {
+					return fmt.Errorf("unable to create client `%s`: %v", client.Name, err)
+				}
+			}
+			for _, project := range imported.Projects {
+				err := repos.Project.Insert(project)
+				if err != nil {
+					return fmt.Errorf("unable to create project `%s` for client `%s`: %v", project.Name, project.Client.Name, err)
+				}
+			}
+		}
+		var rowErrors []error
+		for _, record := range imported.Sessions {
+			err := checkImportedOverlaps(repos.Session, record)
+			if err != nil {
+				rowErrors = append(rowErrors, err)
+				continue
+			}
+			err = repos.Session.Insert(&record.Session, importDryRun)
+			if err != nil {
+				return importer.RowError{Row: record.Row, Err: err}
+			}
+		}
+		return errors.Join(rowErrors...)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to import sessions, nothing was imported: %v", err)
+	}
+
+	if importDryRun {
+		for _, client := range imported.Clients {
+			rootCmd.Printf("Would create client %s\n", client.String())
+		}
+		for _, project := range imported.Projects {
+			rootCmd.Printf("Would create project %s for %s\n", project.Name, project.Client.Name)
+		}
+		rootCmd.Printf("Would import %d session(s), skipping %d duplicate(s)\n", len(imported.Sessions), len(imported.Duplicates))
+	} else {
+		for _, client := range imported.Clients {
+			rootCmd.Printf("Created client %s\n", client.String())
+		}
+		for _, project := range imported.Projects {
+			rootCmd.Printf("Created project %s for %s\n", project.Name, project.Client.Name)
+		}
+		rootCmd.Printf("Imported %d session(s), skipped %d duplicate(s)\n", len(imported.Sessions), len(imported.Duplicates))
+	}
+	return nil
+}
+
+// checkImportedOverlaps applies the overlap policy to the imported session,
+// against the stored sessions and the rows imported before it.
+func checkImportedOverlaps(repo repositories.SessionRepository, record importer.Record) error {
+	checker := Puncher.WithRepository(repo)
+	if Puncher.Warn != nil {
+		checker.Warn = func(err error) {
+			Puncher.Warn(importer.RowError{Row: record.Row, Err: err})
+		}
+	}
+	err := checker.CheckOverlaps(record.Session)
+	if err != nil {
+		return importer.RowError{Row: record.Row, Err: err}
+	}
+	return nil
+}
+
+// importedRecords are the records of an import bound to their clients and
+// projects, along with the clients and projects that have to be created.
+type importedRecords struct {
+	Sessions   []importer.Record
+	Duplicates []importer.Record
+	Clients    []*models.Client
+	Projects   []*models.Project
+}
+
+// resolveImportedRecords binds the records to their clients and projects,
+// and separates records that are similar to stored sessions or to earlier
+// records of the same file. Nothing is stored, so that an invalid file leaves
+// no clients or projects behind.
+func resolveImportedRecords(records []importer.Record) (importedRecords, error) {
+	clients := make(map[string]*models.Client)
+	newClients := make(map[string]bool)
+	projects := make(map[string]*models.Project)
+	existingSessions := make(map[string][]models.Session)
+	var imported importedRecords
+	var rowErrors []error
+
+	for _, record := range records {
+		session := record.Session
+		key := strings.ToLower(session.Client.Name)
+		client, ok := clients[key]
+		if !ok {
+			var err error
+			client, err = ClientRepository.SafeGetByName(session.Client.Name)
+			if err != nil {
+				return importedRecords{}, err
+			}
+			if client == nil {
+				client, err = newImportedClient(session.Client.Name)
+				if err != nil {
+					return importedRecords{}, err
+				}
+				newClients[key] = true
+				imported.Clients = append(imported.Clients, client)
+			} else {
+				stored, err := SessionRepository.GetAllSessions(*client)
+				if err != nil {
+					return importedRecords{}, err
+				}
+				existingSessions[key] = *stored
+			}
+			clients[key] = client
+		}
+		session.Client = *client
+
+		if session.Project != nil {
+			projectKey := key + "/" + strings.ToLower(session.Project.Name)
+			project, ok := projects[projectKey]
+			if !ok {
+				var err error
+				project, err = getImportedProject(*client, session.Project.Name, newClients[key])
+				if err != nil {
+					return importedRecords{}, err
+				}
+				if project == nil {
+					project = &models.Project{Name: session.Project.Name, Client: *client}
+					imported.Projects = append(imported.Projects, project)
+				}
+				projects[projectKey] = project
+			}
+			session.Project = project
+		}
+
+		if importNewIDs {
+			session.ID = 0
+		}
+		record.Session = session
+
+		if isSimilarToAny(session, existingSessions[key]) {
+			imported.Duplicates = append(imported.Duplicates, record)
+			continue
+		}
+
+		if session.ID != 0 {
+			_, err := SessionRepository.GetSessionByID(session.ID)
+			if err == nil {
+				rowErrors = append(rowErrors, importer.RowError{
+					Row: record.Row,
+					Err: fmt.Errorf("session ID %d already exists, use --new-ids to assign new IDs", session.ID),
+				})
+				continue
+			} else if err != repositories.ErrSessionNotFound {
+				return importedRecords{}, err
+			}
+		}
+
+		existingSessions[key] = append(existingSessions[key], session)
+		imported.Sessions = append(imported.Sessions, record)
+	}
+	return imported, errors.Join(rowErrors...)
+}
+
+// newImportedClient returns a client that only exists in the imported file,
+// its rate and currency are prompted for unless --yes is given, in which case
+// the configured defaults are used.
+func newImportedClient(name string) (*models.Client, error) {
+	client := &models.Client{
+		Name:     name,
+		PPH:      Config.Import.DefaultPPH,
+		Currency: Config.Settings.Currency,
+	}
+	if !importDefaults {
+		rootCmd.Printf("Client `%s` does not exist, hourly rate [%d]: ", name, client.PPH)
+		var answer string
+		n, _ := fmt.Scanln(&answer)
+		if n == 1 {
+			price, err := strconv.ParseUint(answer, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid price %s", answer)
+			}
+			client.PPH = uint16(price)
+		}
+		rootCmd.Printf("Currency [%s]: ", client.Currency)
+		n, _ = fmt.Scanln(&answer)
+		if n == 1 {
+			client.Currency = answer
+		}
+	}
+	return client, nil
+}
+
+// getImportedProject returns the stored project of the client, or nil when it
+// has to be created. Clients that are created by the import have no projects.
+func getImportedProject(client models.Client, name string, newClient bool) (*models.Project, error) {
+	if newClient {
+		return nil, nil
+	}
+	project, err := ProjectRepository.GetByName(client, name)
+	if err == repositories.ErrProjectNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func isSimilarToAny(session models.Session, sessions []models.Session) bool {
+	for _, existing := range sessions {
+		if existing.Similar(session) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importCSVCmd)
+	importCmd.AddCommand(importTogglCmd)
+	importCmd.AddCommand(importClockifyCmd)
+	importCmd.AddCommand(importTimewarriorCmd)
+	importCmd.PersistentFlags().BoolVar(&importDryRun, "dry-run", false, "Validate the import without storing any session")
+	importCmd.PersistentFlags().BoolVar(&importNewIDs, "new-ids", false, "Assign new IDs instead of keeping the imported ones")
+	importCmd.PersistentFlags().BoolVarP(&importDefaults, "yes", "y", false, "Create missing clients with the configured defaults without prompting")
+	importCSVCmd.Flags().StringToStringVar(&importColumns, "column", nil, "Map a session field to a CSV header (e.g. client=Customer)")
+}
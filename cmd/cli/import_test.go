@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dormunis/punch/pkg/config"
+	"github.com/dormunis/punch/pkg/importer"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/puncher"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_ResolveImportedRecords_SkipsDuplicates(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	ClientRepository = repositories.NewMockClientRepository(mockCtrl)
+	importNewIDs = true
+	defer func() { importNewIDs = false }()
+
+	existing := createSampleSession()
+	fresh := createSampleSession()
+	fresh.Start = fresh.Start.AddDate(0, 0, -1)
+	fresh.End = fresh.End.AddDate(0, 0, -1)
+
+	ClientRepository.(*repositories.MockClientRepository).EXPECT().
+		SafeGetByName(existing.Client.Name).
+		Return(&existing.Client, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessions(existing.Client).
+		Return(&[]models.Session{existing}, nil).
+		Times(1)
+
+	records := []importer.Record{
+		{Row: 2, Session: existing},
+		{Row: 3, Session: fresh},
+		{Row: 4, Session: fresh},
+	}
+	imported, err := resolveImportedRecords(records)
+
+	assert.NoError(t, err)
+	assert.Len(t, imported.Sessions, 1)
+	assert.Equal(t, 3, imported.Sessions[0].Row)
+	assert.Equal(t, uint32(0), imported.Sessions[0].Session.ID)
+	assert.Len(t, imported.Duplicates, 2)
+	assert.Empty(t, imported.Clients)
+}
+
+func TestCli_ResolveImportedRecords_CreatesMissingClientWithDefaults(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ClientRepository = repositories.NewMockClientRepository(mockCtrl)
+	previousConfig := Config
+	Config = &config.Config{
+		Settings: config.Settings{Currency: "EUR"},
+		Import:   config.Import{DefaultPPH: 80},
+	}
+	importDefaults, importNewIDs = true, true
+	defer func() {
+		Config = previousConfig
+		importDefaults, importNewIDs = false, false
+	}()
+
+	expectedClient := models.Client{Name: "Test Client", PPH: 80, Currency: "EUR"}
+	ClientRepository.(*repositories.MockClientRepository).EXPECT().
+		SafeGetByName("Test Client").
+		Return(nil, nil).
+		Times(1)
+
+	records := []importer.Record{{Row: 2, Session: createSampleSession()}}
+	imported, err := resolveImportedRecords(records)
+
+	assert.NoError(t, err)
+	assert.Len(t, imported.Sessions, 1)
+	assert.Equal(t, expectedClient, imported.Sessions[0].Session.Client)
+	assert.Equal(t, []*models.Client{&expectedClient}, imported.Clients)
+}
+
+func TestCli_ImportSessions_InvalidRowCreatesNoClients(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	ClientRepository = repositories.NewMockClientRepository(mockCtrl)
+	previousConfig := Config
+	Config = &config.Config{Settings: config.Settings{Currency: "EUR"}}
+	importDefaults = true
+	defer func() {
+		Config = previousConfig
+		importDefaults = false
+	}()
+
+	ClientRepository.(*repositories.MockClientRepository).EXPECT().
+		SafeGetByName("Initech").
+		Return(nil, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetSessionByID(uint32(7)).
+		Return(&models.Session{ID: 7}, nil).
+		Times(1)
+
+	path := filepath.Join(t.TempDir(), "sessions.csv")
+	err := os.WriteFile(path, []byte("id,date,client,start_time,end_time\n7,2026-10-12,Initech,09:00:00,10:00:00\n"), 0644)
+	assert.NoError(t, err)
+
+	csvImporter := importer.NewCSVImporter(importer.DefaultCSVColumns(), "", "")
+	err = importSessions(csvImporter, path)
+
+	assert.ErrorContains(t, err, "nothing was imported")
+}
+
+func TestCli_ImportSessions_RejectsOverlappingRows(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	ClientRepository = repositories.NewMockClientRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+	Puncher.OverlapPolicy = puncher.OVERLAP_POLICY_REJECT
+	committed := false
+	Transaction = func(fn func(repos repositories.Repositories) error) error {
+		err := fn(repositories.Repositories{Session: SessionRepository})
+		committed = err == nil
+		return err
+	}
+	defer func() { Transaction = nil }()
+
+	client := models.Client{Name: "Acme", Currency: "EUR"}
+	stored := createSampleSession()
+	ClientRepository.(*repositories.MockClientRepository).EXPECT().
+		SafeGetByName("Acme").
+		Return(&client, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessions(client).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+	gomock.InOrder(
+		SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+			GetOverlappingSessions(gomock.Any(), nil).
+			Return(&[]models.Session{stored}, nil),
+		SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+			GetOverlappingSessions(gomock.Any(), nil).
+			Return(&[]models.Session{}, nil),
+	)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		Insert(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	path := filepath.Join(t.TempDir(), "sessions.csv")
+	err := os.WriteFile(path, []byte("date,client,start_time,end_time\n"+
+		"2026-10-12,Acme,09:00:00,10:00:00\n"+
+		"2026-10-13,Acme,09:00:00,10:00:00\n"), 0644)
+	assert.NoError(t, err)
+
+	csvImporter := importer.NewCSVImporter(importer.DefaultCSVColumns(), "", "")
+	err = importSessions(csvImporter, path)
+
+	assert.ErrorContains(t, err, "nothing was imported")
+	assert.ErrorContains(t, err, "row 2")
+	assert.ErrorContains(t, err, "session overlaps")
+	assert.False(t, committed)
+}
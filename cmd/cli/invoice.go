@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dormunis/punch/pkg/invoice"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/spf13/cobra"
+)
+
+var (
+	invoiceMonth        string
+	invoiceGroupBy      string
+	invoiceFormat       string
+	invoiceMarkInvoiced bool
+)
+
+var invoiceCmd = &cobra.Command{
+	Use:   "invoice [client]",
+	Short: "generate an invoice for a client's sessions",
+	Long: `Generate an invoice for a client's finished sessions within a month,
+    or within an explicit range given with --from and --to. Every invoice is given a sequential number. Sessions that were already
+    marked as invoiced or paid are not billed again.`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		switch invoice.GroupBy(invoiceGroupBy) {
+		case invoice.GROUP_BY_DAY, invoice.GROUP_BY_NOTE:
+		default:
+			return invoice.ErrUnsupportedGroupBy
+		}
+		switch invoice.Format(invoiceFormat) {
+		case invoice.FORMAT_TEXT, invoice.FORMAT_MARKDOWN, invoice.FORMAT_HTML:
+		default:
+			return invoice.ErrUnsupportedFormat
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := ClientRepository.GetByName(args[0])
+		if err != nil {
+			return fmt.Errorf("unable to get client: %v", err)
+		}
+
+		var from, to time.Time
+		if fromDate != "" || toDate != "" {
+			from, to, err = ParseDateRange(fromDate, toDate, client)
+		} else {
+			from, to, err = parseInvoiceMonth(invoiceMonth)
+		}
+		if err != nil {
+			return err
+		}
+
+		sessions, err := getInvoiceableSessions(*client, from, to)
+		if err != nil {
+			return err
+		}
+
+		if len(sessions) == 0 {
+			return invoice.ErrNoSessions
+		}
+
+		newInvoice := models.Invoice{
+			Client:   *client,
+			IssuedAt: time.Now(),
+			From:     from,
+			To:       to,
+		}
+		document, err := invoice.NewDocument(newInvoice, sessions, invoice.GroupBy(invoiceGroupBy))
+		if err != nil {
+			return err
+		}
+		rates, err := GetExchangeRates()
+		if err != nil {
+			return err
+		}
+		if rates != nil {
+			err = document.Convert(sessions, *rates)
+			if err != nil {
+				return err
+			}
+		}
+
+		// the invoice number is given back when the invoice cannot be rendered
+		var buf *bytes.Buffer
+		err = Transaction(func(repos repositories.Repositories) error {
+			err := repos.Invoice.Insert(&newInvoice)
+			if err != nil {
+				return fmt.Errorf("unable to create invoice: %v", err)
+			}
+			document.Number = newInvoice.Number
+			buf, err = document.Render(invoice.Format(invoiceFormat))
+			if err != nil {
+				return err
+			}
+
+			if invoiceMarkInvoiced {
+				for _, session := range sessions {
+					session.InvoiceNumber = newInvoice.Number
+					session.Status = models.SESSION_STATUS_INVOICED
+					err = repos.Session.Update(&session, false)
+					if err != nil {
+						return fmt.Errorf("unable to mark session %d as invoiced: %v", session.ID, err)
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		rootCmd.Print(buf.String())
+		return nil
+	},
+}
+
+func parseInvoiceMonth(month string) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation("2006-01", month, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid month format (format: YYYY-MM)")
+	}
+	return from, from.AddDate(0, 1, 0), nil
+}
+
+// getInvoiceableSessions returns the client's finished sessions that started
+// within the given period and were not invoiced or paid yet.
+func getInvoiceableSessions(client models.Client, from time.Time, to time.Time) ([]models.Session, error) {
+	sessions, err := SessionRepository.GetAllSessionsStartedBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	var invoiceable []models.Session
+	for _, session := range *sessions {
+		if !strings.EqualFold(session.Client.Name, client.Name) ||
+			!session.Finished() ||
+			session.Invoiced() ||
+			session.Locked() {
+			continue
+		}
+		invoiceable = append(invoiceable, session)
+	}
+	return invoiceable, nil
+}
+
+func init() {
+	rootCmd.AddCommand(invoiceCmd)
+	invoiceCmd.Flags().StringVar(&invoiceMonth, "month", time.Now().Format("2006-01"), "Month to invoice (format: YYYY-MM), defaults to the current month")
+	invoiceCmd.Flags().StringVar(&fromDate, "from", "", "Invoice sessions from a specific date (e.g. 2026-09-01)")
+	invoiceCmd.Flags().StringVar(&toDate, "to", "", "Invoice sessions up to a specific date, which is included entirely (e.g. 2026-09-15)")
+	invoiceCmd.Flags().StringVar(&invoiceGroupBy, "group-by", string(invoice.GROUP_BY_DAY), "Group invoice lines by day or note")
+	invoiceCmd.Flags().StringVarP(&invoiceFormat, "output", "o", string(invoice.FORMAT_TEXT), "Invoice format: text, markdown or html")
+	invoiceCmd.Flags().BoolVar(&invoiceMarkInvoiced, "mark-invoiced", false, "Mark the invoiced sessions as invoiced, locking them from edits")
+	invoiceCmd.MarkFlagsMutuallyExclusive("month", "from")
+	invoiceCmd.MarkFlagsMutuallyExclusive("month", "to")
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/database"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_GetInvoiceableSessions_SessionEndingAfterMidnightBelongsToItsStart(t *testing.T) {
+	db, err := database.NewDatabase("sqlite3", filepath.Join(t.TempDir(), "punch.db"))
+	assert.NoError(t, err)
+	SessionRepository = repositories.NewGORMSessionRepository(db)
+	client := models.Client{Name: "Acme", PPH: 100, Currency: "USD"}
+	assert.NoError(t, repositories.NewGORMClientRepository(db).Insert(&client))
+
+	start := time.Date(2026, time.September, 30, 23, 0, 0, 0, time.Local)
+	session := models.Session{Client: client, Start: start, End: start.Add(2 * time.Hour)}
+	assert.NoError(t, SessionRepository.Insert(&session, false))
+
+	from, to, err := parseInvoiceMonth("2026-09")
+	assert.NoError(t, err)
+	september, err := getInvoiceableSessions(client, from, to)
+	assert.NoError(t, err)
+	assert.Len(t, september, 1)
+	assert.True(t, september[0].Start.Equal(start))
+
+	from, to, err = parseInvoiceMonth("2026-10")
+	assert.NoError(t, err)
+	october, err := getInvoiceableSessions(client, from, to)
+	assert.NoError(t, err)
+	assert.Empty(t, october)
+}
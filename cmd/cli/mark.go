@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	markInvoiceNumber uint32
+)
+
+var markCmd = &cobra.Command{
+	Use:   "mark [unbilled|invoiced|paid] [id|time]",
+	Short: "set the billing status of sessions",
+	Long: `Set the billing status of sessions (defaults to today's sessions).
+    Invoiced and paid sessions are locked from edits and deletion unless
+    --force is given.`,
+	Args: cobra.RangeArgs(1, 2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		reportTimeframe, err = ExtractTimeframeFromFlags()
+		if err != nil {
+			return err
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, err := models.ParseSessionStatus(args[0])
+		if err != nil {
+			return err
+		}
+
+		var sessions []models.Session
+		if markInvoiceNumber != 0 {
+			sessions, err = getInvoiceSessions(markInvoiceNumber)
+			if err != nil {
+				return err
+			}
+		} else if len(args) == 1 {
+			sessions = GetSessionsWithTimeframe(*reportTimeframe)
+		} else {
+			sessions = GetRelativeSessionsFromArgs(args[1:], currentClientName)
+		}
+
+		markedCount := 0
+		for _, session := range sessions {
+			if session.Status == status {
+				continue
+			}
+			err = SessionRepository.SetStatus(&session, status)
+			if err != nil {
+				rootCmd.Printf("Unable to mark session %d: %v\n", session.ID, err)
+				continue
+			}
+			markedCount++
+		}
+		rootCmd.Printf("Marked %d session(s) as %s\n", markedCount, status)
+		return nil
+	},
+}
+
+func getInvoiceSessions(invoiceNumber uint32) ([]models.Session, error) {
+	sessions, err := SessionRepository.GetAllSessionsAllClients()
+	if err != nil {
+		return nil, err
+	}
+	var invoiceSessions []models.Session
+	for _, session := range *sessions {
+		if session.InvoiceNumber == invoiceNumber {
+			invoiceSessions = append(invoiceSessions, session)
+		}
+	}
+	return invoiceSessions, nil
+}
+
+func init() {
+	currentYear, currentMonth, _ := time.Now().Date()
+	rootCmd.AddCommand(markCmd)
+	markCmd.Flags().StringVarP(&currentClientName, "client", "c", "", "Specify the client name")
+	markCmd.Flags().Uint32Var(&markInvoiceNumber, "invoice", 0, "Mark the sessions of a specific invoice number")
+	markCmd.Flags().BoolVar(&dayReport, "day", false, "Mark sessions of this current day")
+	markCmd.Flags().StringVar(&weekReport, "week", "", "Mark sessions of a specific week (format: -N or YYYY-Www), leave empty for current week")
+	markCmd.Flags().StringVar(&monthReport, "month", "", "Mark sessions of a specific month (format: -N, MM or YYYY-MM), leave empty for current month")
+	markCmd.Flags().StringVar(&yearReport, "year", "", "Mark sessions of a specific year (format: -N or YYYY), leave empty for current year")
+	markCmd.Flags().BoolVar(&prevReport, "prev", false, "Mark sessions of the period before the selected day, week, month or year")
+	markCmd.Flags().BoolVarP(&allReport, "all", "a", false, "Mark all sessions")
+	markCmd.Flags().StringVar(&fromDate, "from", "", "Mark sessions from a specific date or time (e.g. 2026-09-01)")
+	markCmd.Flags().StringVar(&toDate, "to", "", "Mark sessions up to a specific date or time, a date is included entirely (e.g. 2026-09-15)")
+	markCmd.Flags().Lookup("month").NoOptDefVal = strconv.Itoa(int(currentMonth))
+	markCmd.Flags().Lookup("week").NoOptDefVal = "0"
+	markCmd.Flags().Lookup("year").NoOptDefVal = strconv.Itoa(currentYear)
+}
@@ -1,19 +1,28 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/puncher"
 	"github.com/spf13/cobra"
 )
 
+var endAll bool
+
 var startCmd = &cobra.Command{
 	Use:   "start [time]",
 	Short: "Starts a new work session",
 	Args:  cobra.MaximumNArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		return GetClientIfExists(currentClientName)
+		err := GetClientIfExists(currentClientName)
+		if err != nil {
+			return err
+		}
+		return GetProjectIfExists(currentProjectName)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		timestamp, _, err := ExtractParsedTimeFromArgs(args, currentClientName)
@@ -21,7 +30,7 @@ var startCmd = &cobra.Command{
 			return err
 		}
 
-		session, err := Puncher.StartSession(*currentClient, timestamp, punchMessage)
+		session, err := Puncher.StartSession(*currentClient, currentProject, timestamp, punchMessage, punchTags...)
 		if err != nil {
 			return err
 		}
@@ -40,31 +49,89 @@ var startCmd = &cobra.Command{
 var endCmd = &cobra.Command{
 	Use:   "end [time]",
 	Short: "End a work session",
-	Args:  cobra.MaximumNArgs(1),
-	PreRunE: func(cmd *cobra.Command, args []string) error {
-		return GetClientIfExists(currentClientName)
-	},
+	Long: `End the open work session. With several open sessions, the session
+    is picked with --client, or all of them are ended with --all.`,
+	Args:    cobra.MaximumNArgs(1),
+	PreRunE: preRunOpenSessionClient,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		timestamp, _, err := ExtractParsedTimeFromArgs(args, currentClientName)
 		if err != nil {
 			return err
 		}
 
-		currentSession, err := SessionRepository.GetLatestSession()
+		var openSessions []models.Session
+		if endAll {
+			openSessions, err = Puncher.OpenSessions(nil)
+			if err == nil && len(openSessions) == 0 {
+				err = puncher.ErrNoOpenSession
+			}
+		} else {
+			var currentSession *models.Session
+			currentSession, err = getOpenSession(cmd)
+			if currentSession != nil {
+				openSessions = append(openSessions, *currentSession)
+			}
+		}
 		if err != nil {
 			return err
 		}
 
-		session, _ := Puncher.EndSession(*currentSession, timestamp, punchMessage)
+		for _, currentSession := range openSessions {
+			session, err := Puncher.EndSession(currentSession, timestamp, punchMessage)
+			if err != nil {
+				return err
+			}
+			err = printEOD(cmd, session)
+			if err != nil {
+				return err
+			}
+		}
+
+		if slices.Contains(Config.Settings.AutoSync, "end") {
+			err = Sync(rootCmd)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var switchCmd = &cobra.Command{
+	Use:   "switch [client] [time]",
+	Short: "End the current work session and start one for another client",
+	Long: `End the current work session and start a new one for the given client
+    at the exact same time, so no gap or overlap is left between them.`,
+	Example: `punch switch Acme
+punch switch Acme -p Website -m "landing page"
+punch switch Acme -- -10m`,
+	Args: cobra.RangeArgs(1, 2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		currentClientName = args[0]
+		err := GetClientIfExists(currentClientName)
+		if err != nil {
+			return err
+		}
+		return GetProjectIfExists(currentProjectName)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timestamp, _, err := ExtractParsedTimeFromArgs(args[1:], currentClientName)
+		if err != nil {
+			return err
+		}
+
+		ended, started, err := Puncher.SwitchSession(*currentClient, currentProject, timestamp, punchMessage, punchTags...)
 		if err != nil {
 			return err
 		}
-		err = printEOD(cmd, session)
+		err = printEOD(cmd, ended)
 		if err != nil {
 			return err
 		}
+		printBOD(cmd, started)
 
-		if slices.Contains(Config.Settings.AutoSync, "end") {
+		if slices.Contains(Config.Settings.AutoSync, "start") ||
+			slices.Contains(Config.Settings.AutoSync, "end") {
 			err = Sync(rootCmd)
 			if err != nil {
 				return err
@@ -74,13 +141,96 @@ var endCmd = &cobra.Command{
 	},
 }
 
+var pauseCmd = &cobra.Command{
+	Use:     "pause [time]",
+	Short:   "Pause the current work session for a break",
+	Args:    cobra.MaximumNArgs(1),
+	PreRunE: preRunOpenSessionClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timestamp, _, err := ExtractParsedTimeFromArgs(args, currentClientName)
+		if err != nil {
+			return err
+		}
+
+		currentSession, err := getOpenSession(cmd)
+		if err != nil {
+			return err
+		}
+
+		session, err := Puncher.PauseSession(*currentSession, timestamp)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Paused at %s for %s\n", timestamp.Format("15:04:05"), session.Client.Name)
+		return nil
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:     "resume [time]",
+	Short:   "Resume a paused work session",
+	Args:    cobra.MaximumNArgs(1),
+	PreRunE: preRunOpenSessionClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timestamp, _, err := ExtractParsedTimeFromArgs(args, currentClientName)
+		if err != nil {
+			return err
+		}
+
+		currentSession, err := getOpenSession(cmd)
+		if err != nil {
+			return err
+		}
+
+		session, err := Puncher.ResumeSession(*currentSession, timestamp)
+		if err != nil {
+			return err
+		}
+		lastBreak := session.Breaks[len(session.Breaks)-1]
+		cmd.Printf("Resumed at %s after a %s break for %s\n",
+			timestamp.Format("15:04:05"),
+			lastBreak.End.Sub(lastBreak.Start).Truncate(time.Second),
+			session.Client.Name,
+		)
+		return nil
+	},
+}
+
+// preRunOpenSessionClient only resolves the client when --client is given,
+// since the default client should not hide another client's open session.
+func preRunOpenSessionClient(cmd *cobra.Command, args []string) error {
+	if !cmd.Flags().Changed("client") {
+		currentClient = nil
+		return nil
+	}
+	return GetClientIfExists(currentClientName)
+}
+
+// getOpenSession returns the open session of --client, or the only open
+// session if no client is given.
+func getOpenSession(cmd *cobra.Command) (*models.Session, error) {
+	var client *models.Client
+	if cmd.Flags().Changed("client") {
+		client = currentClient
+	}
+	session, err := Puncher.OpenSession(client)
+	if errors.Is(err, puncher.ErrMultipleOpenSessions) {
+		return nil, fmt.Errorf("%w, pick one with --client", err)
+	}
+	return session, err
+}
+
 func printBOD(_ *cobra.Command, session *models.Session) {
+	if session.Project != nil {
+		fmt.Printf("Clocked in at %s for %s (%s)\n", session.Start.Format("15:04:05"), session.Client.Name, session.Project.Name)
+		return
+	}
 	fmt.Printf("Clocked in at %s for %s\n", session.Start.Format("15:04:05"), session.Client.Name)
 }
 
 func printEOD(cmd *cobra.Command, session *models.Session) error {
 	earnings, err := session.Earnings()
-	duration := session.End.Sub(session.Start)
+	duration := session.WorkDuration().Truncate(time.Second)
 	if err != nil {
 		return err
 	}
@@ -96,9 +246,21 @@ func printEOD(cmd *cobra.Command, session *models.Session) error {
 
 func init() {
 	startCmd.Flags().StringVarP(&currentClientName, "client", "c", "", "Specify the client name")
+	startCmd.Flags().StringVarP(&currentProjectName, "project", "p", "", "Specify the client's project")
 	startCmd.Flags().StringVarP(&punchMessage, "message", "m", "", "Comment or message")
+	startCmd.Flags().StringSliceVarP(&punchTags, "tag", "t", nil, "Tag the session (can be repeated or comma separated)")
 	endCmd.Flags().StringVarP(&currentClientName, "client", "c", "", "Specify the client name")
 	endCmd.Flags().StringVarP(&punchMessage, "message", "m", "", "Comment or message")
+	endCmd.Flags().BoolVar(&endAll, "all", false, "End all open sessions")
+	endCmd.MarkFlagsMutuallyExclusive("client", "all")
+	switchCmd.Flags().StringVarP(&currentProjectName, "project", "p", "", "Specify the client's project")
+	switchCmd.Flags().StringVarP(&punchMessage, "message", "m", "", "Comment or message for the new session")
+	switchCmd.Flags().StringSliceVarP(&punchTags, "tag", "t", nil, "Tag the new session (can be repeated or comma separated)")
+	pauseCmd.Flags().StringVarP(&currentClientName, "client", "c", "", "Specify the client name")
+	resumeCmd.Flags().StringVarP(&currentClientName, "client", "c", "", "Specify the client name")
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(endCmd)
+	rootCmd.AddCommand(switchCmd)
+	rootCmd.AddCommand(pauseCmd)
+	rootCmd.AddCommand(resumeCmd)
 }
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/config"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/puncher"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createOpenSessions() []models.Session {
+	first := createSampleSession()
+	first.Start = time.Now().Add(-time.Hour)
+	first.End = models.NULL_TIME
+	second := createSampleSession()
+	second.ID = 2
+	second.Client = models.Client{Name: "Other Client", Currency: "EUR"}
+	second.Start = time.Now().Add(-2 * time.Hour)
+	second.End = models.NULL_TIME
+	return []models.Session{first, second}
+}
+
+func TestCli_End_MultipleOpenSessionsRequiresClient(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+
+	openSessions := createOpenSessions()
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetOpenSessions(nil).
+		Return(&openSessions, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	_, err := executeCommand(t, []string{"end"})
+
+	assert.ErrorIs(t, err, puncher.ErrMultipleOpenSessions)
+	assert.ErrorContains(t, err, "Test Client, Other Client")
+}
+
+func TestCli_End_AllEndsEveryOpenSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+	previousConfig := Config
+	Config = &config.Config{}
+	defer func() {
+		Config = previousConfig
+		endAll = false
+	}()
+
+	openSessions := createOpenSessions()
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetOpenSessions(nil).
+		Return(&openSessions, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		Update(gomock.Any(), false).
+		Return(nil).
+		Times(2)
+
+	_, err := executeCommand(t, []string{"end", "--all"})
+
+	assert.NoError(t, err)
+}
+
+func TestCli_GetSession_OpenListsOpenSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+	defer func() { openOnly = false }()
+
+	openSessions := createOpenSessions()
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetOpenSessions(nil).
+		Return(&openSessions, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsBetweenDates(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	content, err := executeCommand(t, []string{"get", "session", "--open"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, content, "Test Client")
+	assert.Contains(t, content, "Other Client")
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dormunis/punch/pkg/editor"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var exchangeRateDate string
+
+var ratesCmd = &cobra.Command{
+	Use:   "rates",
+	Short: "List the exchange rates to the reporting currency",
+	Long: `List the exchange rate table used to convert earnings to the reporting
+    currency. A rate is the value of one unit of a currency in the reporting
+    currency, it is in force from its date until the next rate of that
+    currency.`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return preRunCheckOutput("text", "csv")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rates, err := ExchangeRateRepository.GetAll()
+		if err != nil {
+			return err
+		}
+		if output == "csv" {
+			return models.SerializeExchangeRatesToCSV(cmd.OutOrStdout(), rates)
+		}
+		if len(rates) == 0 {
+			return ErrNoAvailableData
+		}
+		cmd.Print(generateExchangeRatesView(rates))
+		return nil
+	},
+}
+
+var ratesSetCmd = &cobra.Command{
+	Use:   "set [currency] [rate]",
+	Short: "Set the exchange rate of a currency",
+	Example: `punch rates set EUR 1.08
+punch rates set EUR 1.07 --date 2026-09-01`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		value, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return fmt.Errorf("%w, rate must be a number", models.ErrInvalidExchangeRate)
+		}
+		date := startOfDay(time.Now())
+		if exchangeRateDate != "" {
+			date, err = time.ParseInLocation("2006-01-02", exchangeRateDate, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid date (format: YYYY-MM-DD): %v", err)
+			}
+		}
+		rate := models.ExchangeRate{
+			Date:     date,
+			Currency: strings.ToUpper(args[0]),
+			Rate:     value,
+		}
+		err = rate.Validate()
+		if err != nil {
+			return err
+		}
+		err = ExchangeRateRepository.Upsert([]models.ExchangeRate{rate})
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Set the %s rate to %s from %s\n",
+			rate.Currency, args[1], rate.Date.Format("2006-01-02"))
+		return nil
+	},
+}
+
+var ratesImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import exchange rates from a CSV of date, currency and rate",
+	Long: `Import exchange rates from a CSV file with a date (YYYY-MM-DD), currency
+    and rate per row. Rates of a currency that already exist for the same
+    date are replaced.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		rates, err := models.DeserializeExchangeRatesFromCSV(file)
+		if err != nil {
+			return err
+		}
+		err = ExchangeRateRepository.Upsert(rates)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Imported %d exchange rate(s)\n", len(rates))
+		return nil
+	},
+}
+
+var ratesEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Interactively edit the exchange rate table",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rates, err := ExchangeRateRepository.GetAll()
+		if err != nil {
+			return err
+		}
+		buf := new(bytes.Buffer)
+		err = models.SerializeExchangeRatesToCSV(buf, rates)
+		if err != nil {
+			return err
+		}
+		err = editor.InteractiveEdit(buf, "csv")
+		if err != nil {
+			return err
+		}
+		editedRates, err := models.DeserializeExchangeRatesFromCSV(buf)
+		if err != nil {
+			return err
+		}
+		err = ExchangeRateRepository.ReplaceAll(editedRates)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Saved %d exchange rate(s)\n", len(editedRates))
+		return nil
+	},
+}
+
+func generateExchangeRatesView(rates []models.ExchangeRate) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+	if !hideHeaders {
+		fmt.Fprintf(writer, "DATE\tCURRENCY\tRATE\n")
+	}
+	for _, rate := range rates {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n",
+			rate.Date.Format("2006-01-02"),
+			rate.Currency,
+			strconv.FormatFloat(rate.Rate, 'f', -1, 64))
+	}
+	writer.Flush()
+	return buffer.String()
+}
+
+// reportingCurrency returns the configured reporting currency, or an empty
+// string when totals are not converted.
+func reportingCurrency() string {
+	if Config == nil {
+		return ""
+	}
+	return strings.ToUpper(Config.Settings.ReportingCurrency)
+}
+
+// GetExchangeRates returns the exchange rate table to the reporting currency,
+// or nil when no reporting currency is configured.
+func GetExchangeRates() (*models.ExchangeRates, error) {
+	currency := reportingCurrency()
+	if currency == "" {
+		return nil, nil
+	}
+	rates, err := ExchangeRateRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return &models.ExchangeRates{Currency: currency, Rates: rates}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(ratesCmd)
+	ratesCmd.AddCommand(ratesSetCmd)
+	ratesCmd.AddCommand(ratesImportCmd)
+	ratesCmd.AddCommand(ratesEditCmd)
+	ratesCmd.Flags().StringVarP(&output, "output", "o", "text", "Specify the output format (text or csv)")
+	ratesCmd.Flags().BoolVar(&hideHeaders, "hide-headers", false, "Hide headers in the text output")
+	ratesSetCmd.Flags().StringVar(&exchangeRateDate, "date", "", "Date from which the rate is in force (format: YYYY-MM-DD), defaults to today")
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/config"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_RatesSet(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ExchangeRateRepository = repositories.NewMockExchangeRateRepository(mockCtrl)
+	defer func() { exchangeRateDate = "" }()
+
+	expected := []models.ExchangeRate{{
+		Date:     time.Date(2026, time.September, 1, 0, 0, 0, 0, time.Local),
+		Currency: "EUR",
+		Rate:     1.08,
+	}}
+	ExchangeRateRepository.(*repositories.MockExchangeRateRepository).EXPECT().
+		Upsert(expected).
+		Return(nil).
+		Times(1)
+
+	content, err := executeCommand(t, []string{"rates", "set", "eur", "1.08", "--date", "2026-09-01"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Set the EUR rate to 1.08 from 2026-09-01\n", content)
+}
+
+func TestCli_RatesSet_InvalidRate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ExchangeRateRepository = repositories.NewMockExchangeRateRepository(mockCtrl)
+
+	_, err := executeCommand(t, []string{"rates", "set", "EUR", "--", "-1"})
+
+	assert.ErrorIs(t, err, models.ErrInvalidExchangeRate)
+}
+
+func TestCli_GenerateSummaryView_ConvertsToReportingCurrency(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ExchangeRateRepository = repositories.NewMockExchangeRateRepository(mockCtrl)
+	defer func(previous *config.Config) { Config = previous }(Config)
+	Config = &config.Config{Settings: config.Settings{ReportingCurrency: "usd"}}
+
+	usd := createSampleSession()
+	euro := createSampleSession()
+	euro.Client = models.Client{Name: "Euro Client", PPH: 100, Currency: "EUR"}
+	sessions := []models.Session{usd, euro}
+	ExchangeRateRepository.(*repositories.MockExchangeRateRepository).EXPECT().
+		GetAll().
+		Return([]models.ExchangeRate{{Date: euro.Start.AddDate(0, 0, -1), Currency: "EUR", Rate: 1.5}}, nil).
+		Times(1)
+
+	content, err := generateSummaryView(&sessions)
+
+	assert.NoError(t, err)
+	lines := strings.Split(content, "\n")
+	assert.True(t, strings.HasPrefix(lines[3], "<all>"))
+	assert.Contains(t, lines[3], "USD")
+	assert.NotContains(t, content, "<all>  EUR")
+}
+
+func TestCli_GenerateSummaryView_MissingExchangeRate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ExchangeRateRepository = repositories.NewMockExchangeRateRepository(mockCtrl)
+	defer func(previous *config.Config) { Config = previous }(Config)
+	Config = &config.Config{Settings: config.Settings{ReportingCurrency: "USD"}}
+
+	euro := createSampleSession()
+	euro.Client = models.Client{Name: "Euro Client", PPH: 100, Currency: "EUR"}
+	sessions := []models.Session{euro}
+	ExchangeRateRepository.(*repositories.MockExchangeRateRepository).EXPECT().
+		GetAll().
+		Return(nil, nil).
+		Times(1)
+
+	_, err := generateSummaryView(&sessions)
+
+	assert.ErrorIs(t, err, models.ErrNoExchangeRate)
+}
+
+func TestCli_Invoice_MissingExchangeRateAllocatesNoNumber(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ClientRepository = repositories.NewMockClientRepository(mockCtrl)
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	ExchangeRateRepository = repositories.NewMockExchangeRateRepository(mockCtrl)
+	defer func(previous *config.Config) { Config = previous }(Config)
+	Config = &config.Config{Settings: config.Settings{ReportingCurrency: "USD"}}
+	defer func() { Transaction = nil }()
+	Transaction = func(fn func(repos repositories.Repositories) error) error {
+		t.Fatal("no invoice number should be allocated")
+		return nil
+	}
+
+	euro := createSampleSession()
+	euro.Client = models.Client{Name: "Euro Client", PPH: 100, Currency: "EUR"}
+	ClientRepository.(*repositories.MockClientRepository).EXPECT().
+		GetByName("Euro Client").
+		Return(&euro.Client, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsStartedBetween(gomock.Any(), gomock.Any()).
+		Return(&[]models.Session{euro}, nil).
+		Times(1)
+	ExchangeRateRepository.(*repositories.MockExchangeRateRepository).EXPECT().
+		GetAll().
+		Return(nil, nil).
+		Times(1)
+
+	_, err := executeCommand(t, []string{"invoice", "Euro Client", "--month", euro.Start.Format("2006-01")})
+
+	assert.ErrorIs(t, err, models.ErrNoExchangeRate)
+}
@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var timesheetRows string
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports of the tracked time",
+}
+
+var reportTimesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Hours worked per day in a grid",
+	Long: `Render a timesheet of the hours worked per day, with a row per client, note
+    or tag and a column per day of the week (the default) or month, along with
+    the row and column totals. A session is counted on the day it started,
+    sessions with several tags are counted once in each of their tag rows.`,
+	Example: `punch report timesheet
+punch report timesheet --week --prev -o markdown
+punch report timesheet --month --rows tag -c Acme
+punch report timesheet --from 2026-09-01 --to 2026-09-15 -o csv`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		err := preRunCheckOutput("text", "csv", "markdown")
+		if err != nil {
+			return err
+		}
+		switch timesheetRows {
+		case "client", "note", "tag":
+		default:
+			return fmt.Errorf("invalid rows: %s, allowed rows are 'client', 'note', 'tag'", timesheetRows)
+		}
+		if getAmountOfTimeFilterFlags() == 0 {
+			weekReport = "0"
+		}
+		reportTimeframe, err = ExtractTimeframeFromFlags()
+		if err != nil {
+			return err
+		}
+		if *reportTimeframe == REPORT_TIMEFRAME_RANGE && rangeStart.IsZero() {
+			return errors.New("a timesheet needs a start date, use --from")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions := GetSessionsWithTimeframe(*reportTimeframe)
+		filteredSessions := FilterSessionsByClient(&sessions, clientName)
+		filteredSessions = FilterSessionsByTags(filteredSessions, tagFilter)
+		if len(*filteredSessions) == 0 {
+			return ErrNoAvailableData
+		}
+
+		startDate := getStartDate(*reportTimeframe)
+		endDate := getEndDate(*reportTimeframe, startDate)
+		sheet := newTimesheet(*filteredSessions, startDate, endDate, timesheetRows)
+
+		var content string
+		var err error
+		switch output {
+		case "csv":
+			content, err = sheet.CSV()
+		case "markdown":
+			content = sheet.Markdown()
+		default:
+			content, err = sheet.Text()
+		}
+		if err != nil {
+			return err
+		}
+		cmd.Print(content)
+		return nil
+	},
+}
+
+// timesheet holds the hours worked per row (a client, note or tag) and day.
+type timesheet struct {
+	rowsBy string
+	days   []time.Time
+	rows   []string
+	hours  map[string][]time.Duration
+	totals []time.Duration
+}
+
+func newTimesheet(sessions []models.Session, startDate time.Time, endDate time.Time, rowsBy string) timesheet {
+	sheet := timesheet{
+		rowsBy: rowsBy,
+		hours:  make(map[string][]time.Duration),
+	}
+	end := endDate.In(time.Local)
+	for day := startOfDay(startDate.In(time.Local)); day.Before(end); day = day.AddDate(0, 0, 1) {
+		sheet.days = append(sheet.days, day)
+	}
+	sheet.totals = make([]time.Duration, len(sheet.days))
+
+	for _, session := range sessions {
+		index := sheet.dayIndex(session.Start)
+		if index < 0 {
+			continue
+		}
+		duration := session.WorkDuration()
+		sheet.totals[index] += duration
+		for _, row := range timesheetRowsOf(session, rowsBy) {
+			if _, ok := sheet.hours[row]; !ok {
+				sheet.hours[row] = make([]time.Duration, len(sheet.days))
+				sheet.rows = append(sheet.rows, row)
+			}
+			sheet.hours[row][index] += duration
+		}
+	}
+	sort.Strings(sheet.rows)
+	return sheet
+}
+
+func timesheetRowsOf(session models.Session, rowsBy string) []string {
+	switch rowsBy {
+	case "note":
+		if session.Note == "" {
+			return []string{"<no note>"}
+		}
+		return []string{session.Note}
+	case "tag":
+		if len(session.Tags) == 0 {
+			return []string{"<untagged>"}
+		}
+		return session.Tags
+	}
+	return []string{session.Client.Name}
+}
+
+func (t timesheet) dayIndex(date time.Time) int {
+	day := startOfDay(date.In(time.Local))
+	for i, sheetDay := range t.days {
+		if sheetDay.Equal(day) {
+			return i
+		}
+	}
+	return -1
+}
+
+func sumDurations(durations []time.Duration) time.Duration {
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+	return total
+}
+
+// table lays out the timesheet as rows of cells, headers and totals included.
+// Empty cells are left blank unless a zero value is given.
+func (t timesheet) table(dayLayout string, zero string) [][]string {
+	formatHours := func(duration time.Duration) string {
+		if duration == 0 {
+			return zero
+		}
+		return fmt.Sprintf("%.2f", duration.Hours())
+	}
+
+	header := []string{strings.ToUpper(t.rowsBy)}
+	for _, day := range t.days {
+		header = append(header, day.Format(dayLayout))
+	}
+	table := [][]string{append(header, "TOTAL")}
+
+	for _, row := range t.rows {
+		line := []string{row}
+		for _, duration := range t.hours[row] {
+			line = append(line, formatHours(duration))
+		}
+		table = append(table, append(line, formatHours(sumDurations(t.hours[row]))))
+	}
+
+	totals := []string{"TOTAL"}
+	for _, duration := range t.totals {
+		totals = append(totals, formatHours(duration))
+	}
+	return append(table, append(totals, formatHours(sumDurations(t.totals))))
+}
+
+func (t timesheet) Text() (string, error) {
+	table := t.table("Mon 01-02", "-")
+	if hideHeaders {
+		table = table[1:]
+	}
+	buffer := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	for _, line := range table {
+		_, err := fmt.Fprintln(w, strings.Join(line, "\t"))
+		if err != nil {
+			return "", err
+		}
+	}
+	err := w.Flush()
+	if err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func (t timesheet) CSV() (string, error) {
+	buffer := new(bytes.Buffer)
+	w := csv.NewWriter(buffer)
+	err := w.WriteAll(t.table("2006-01-02", "0.00"))
+	if err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func (t timesheet) Markdown() string {
+	table := t.table("Mon 01-02", "")
+	var buffer strings.Builder
+	for i, line := range table {
+		for j, cell := range line {
+			line[j] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		buffer.WriteString("| " + strings.Join(line, " | ") + " |\n")
+		if i == 0 {
+			buffer.WriteString("|---" + strings.Repeat("|--:", len(line)-1) + "|\n")
+		}
+	}
+	return buffer.String()
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportTimesheetCmd)
+	reportTimesheetCmd.Flags().StringVarP(&clientName, "client", "c", "", "Only report a specific client")
+	reportTimesheetCmd.Flags().StringSliceVarP(&tagFilter, "tag", "t", nil, "Only report sessions with any of the given tags")
+	reportTimesheetCmd.Flags().StringVar(&timesheetRows, "rows", "client", "Row per client, note or tag")
+	reportTimesheetCmd.Flags().StringVar(&weekReport, "week", "", "Report a specific week (format: -N or YYYY-Www), leave empty for current week")
+	reportTimesheetCmd.Flags().StringVar(&monthReport, "month", "", "Report a specific month (format: -N, MM or YYYY-MM), leave empty for current month")
+	reportTimesheetCmd.Flags().BoolVar(&prevReport, "prev", false, "Report the week or month before the selected one")
+	reportTimesheetCmd.Flags().StringVar(&fromDate, "from", "", "Report from a specific date (e.g. 2026-09-01)")
+	reportTimesheetCmd.Flags().StringVar(&toDate, "to", "", "Report up to a specific date, which is included entirely (e.g. 2026-09-15)")
+	reportTimesheetCmd.Flags().StringVarP(&output, "output", "o", "text", "Specify the output format (text, csv or markdown)")
+	reportTimesheetCmd.Flags().BoolVar(&hideHeaders, "hide-headers", false, "Hide headers in the text output")
+	reportTimesheetCmd.Flags().Lookup("week").NoOptDefVal = "0"
+	reportTimesheetCmd.Flags().Lookup("month").NoOptDefVal = "0"
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTimesheetSessions() (time.Time, []models.Session) {
+	monday := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local)
+	acme := models.Client{Name: "Acme"}
+	globex := models.Client{Name: "Globex"}
+	return monday, []models.Session{
+		{ID: 1, Client: acme, Start: monday.Add(9 * time.Hour), End: monday.Add(11 * time.Hour), Tags: []string{"dev"}},
+		{ID: 2, Client: globex, Start: monday.Add(13 * time.Hour), End: monday.Add(14*time.Hour + 30*time.Minute)},
+		{ID: 3, Client: acme, Start: monday.AddDate(0, 0, 2).Add(9 * time.Hour), End: monday.AddDate(0, 0, 2).Add(10 * time.Hour), Tags: []string{"dev", "ops"}},
+	}
+}
+
+func TestCli_Timesheet_GroupsHoursByClientAndDay(t *testing.T) {
+	monday, sessions := createTimesheetSessions()
+
+	sheet := newTimesheet(sessions, monday, monday.AddDate(0, 0, 7), "client")
+	table := sheet.table("01-02", "")
+
+	assert.Equal(t, []string{"CLIENT", "10-12", "10-13", "10-14", "10-15", "10-16", "10-17", "10-18", "TOTAL"}, table[0])
+	assert.Equal(t, []string{"Acme", "2.00", "", "1.00", "", "", "", "", "3.00"}, table[1])
+	assert.Equal(t, []string{"Globex", "1.50", "", "", "", "", "", "", "1.50"}, table[2])
+	assert.Equal(t, []string{"TOTAL", "3.50", "", "1.00", "", "", "", "", "4.50"}, table[3])
+}
+
+func TestCli_Timesheet_TagRowsCountSessionsOncePerTag(t *testing.T) {
+	monday, sessions := createTimesheetSessions()
+
+	sheet := newTimesheet(sessions, monday, monday.AddDate(0, 0, 3), "tag")
+	table := sheet.table("01-02", "0")
+
+	assert.Equal(t, []string{"<untagged>", "1.50", "0", "0", "1.50"}, table[1])
+	assert.Equal(t, []string{"dev", "2.00", "0", "1.00", "3.00"}, table[2])
+	assert.Equal(t, []string{"ops", "0", "0", "1.00", "1.00"}, table[3])
+	assert.Equal(t, []string{"TOTAL", "3.50", "0", "1.00", "4.50"}, table[4])
+}
+
+func TestCli_Timesheet_Markdown(t *testing.T) {
+	monday, sessions := createTimesheetSessions()
+
+	sheet := newTimesheet(sessions[:1], monday, monday.AddDate(0, 0, 2), "client")
+
+	assert.Equal(t, "| CLIENT | Mon 10-12 | Tue 10-13 | TOTAL |\n"+
+		"|---|--:|--:|--:|\n"+
+		"| Acme | 2.00 |  | 2.00 |\n"+
+		"| TOTAL | 2.00 |  | 2.00 |\n", sheet.Markdown())
+}
+
+func TestCli_Timesheet_DaysAreLocal(t *testing.T) {
+	pinLocation(t, "Europe/Berlin")
+	monday, sessions := createTimesheetSessions()
+
+	sheet := newTimesheet(sessions, monday.In(time.UTC), monday.AddDate(0, 0, 2).In(time.UTC), "client")
+
+	assert.Equal(t, []time.Time{monday, monday.AddDate(0, 0, 1)}, sheet.days)
+	assert.Equal(t, []time.Duration{3*time.Hour + 30*time.Minute, 0}, sheet.totals)
+}
+
+func TestCli_ReportTimesheet_CSV(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	dayReport = false
+	defer func() { fromDate, toDate, output = "", "", "text" }()
+
+	monday, sessions := createTimesheetSessions()
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsBetweenDates(gomock.Any(), gomock.Any()).
+		Return(&sessions, nil).
+		Times(1)
+
+	content, err := executeCommand(t, []string{"report", "timesheet",
+		"--from", monday.Format("2006-01-02"), "--to", "2026-10-13", "-o", "csv"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "CLIENT,2026-10-12,2026-10-13,TOTAL\n"+
+		"Acme,2.00,0.00,2.00\n"+
+		"Globex,1.50,0.00,1.50\n"+
+		"TOTAL,3.50,0.00,3.50\n", content)
+}
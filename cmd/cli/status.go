@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusFormat string
+
+	ErrNoActiveSession = errors.New("no active session")
+)
+
+// StatusSession describes an open session in `punch status --format`
+// templates.
+type StatusSession struct {
+	Paused   bool
+	Client   string
+	Project  string
+	Start    time.Time
+	Duration string
+	Earnings string
+	Currency string
+	Note     string
+	Tags     string
+}
+
+// Status is the data available to `punch status --format` templates. The
+// fields of the latest open session are promoted, all open sessions are
+// listed in Sessions.
+type Status struct {
+	Active bool
+	StatusSession
+	Sessions []StatusSession
+	Today    string
+	Week     string
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the active work session",
+	Long: `Show the active work session along with today's and this week's
+    totals. Exits with a non-zero code when no session is active.
+
+    The output can be customized with a Go template, for shell prompts or
+    status bars. Available fields: .Active, .Paused, .Client, .Project,
+    .Start, .Duration, .Earnings, .Currency, .Note, .Tags, .Today and .Week.
+    With concurrent sessions, these refer to the latest open session, and
+    .Sessions lists all of them`,
+	Example: `punch status
+punch status --format '{{.Client}} {{.Duration}}'
+punch status --format '{{if .Paused}}paused{{else}}{{.Duration}}{{end}}'
+punch status --format '{{range .Sessions}}{{.Client}} {{.Duration}} {{end}}'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, err := getStatus(time.Now())
+		if err != nil {
+			return err
+		}
+
+		var content string
+		if statusFormat != "" {
+			content, err = renderStatusTemplate(status, statusFormat)
+		} else {
+			content = generateStatusView(status)
+		}
+		if err != nil {
+			return err
+		}
+		cmd.Print(content)
+
+		if !status.Active {
+			return ErrNoActiveSession
+		}
+		return nil
+	},
+}
+
+func getStatus(now time.Time) (*Status, error) {
+	status := &Status{}
+
+	openSessions, err := Puncher.OpenSessions(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range openSessions {
+		earnings, _ := session.Earnings()
+		status.Sessions = append(status.Sessions, StatusSession{
+			Paused:   session.Paused(),
+			Client:   session.Client.Name,
+			Project:  session.ProjectName(),
+			Start:    session.Start,
+			Duration: models.FormatDuration(session.WorkDuration()),
+			Earnings: fmt.Sprintf("%.2f", earnings),
+			Currency: session.Client.Currency,
+			Note:     session.Note,
+			Tags:     strings.Join(session.Tags, ","),
+		})
+	}
+	if len(status.Sessions) > 0 {
+		status.Active = true
+		status.StatusSession = status.Sessions[0]
+	}
+
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	today, err := totalWorkDuration(startOfDay, now)
+	if err != nil {
+		return nil, err
+	}
+	week, err := totalWorkDuration(getStartDate(REPORT_TIMEFRAME_WEEK), now)
+	if err != nil {
+		return nil, err
+	}
+	status.Today = models.FormatDuration(today)
+	status.Week = models.FormatDuration(week)
+	return status, nil
+}
+
+// totalWorkDuration sums up the work of all sessions started since the given
+// time, including the active session.
+func totalWorkDuration(since time.Time, now time.Time) (time.Duration, error) {
+	sessions, err := SessionRepository.GetAllSessionsBetweenDates(since, now.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, err
+	}
+	var total time.Duration
+	for _, session := range *sessions {
+		total += session.WorkDuration()
+	}
+	return total, nil
+}
+
+func generateStatusView(status *Status) string {
+	var buffer bytes.Buffer
+	for _, session := range status.Sessions {
+		state := "Clocked in"
+		if session.Paused {
+			state = "Paused"
+		}
+		client := session.Client
+		if session.Project != "" {
+			client = fmt.Sprintf("%s (%s)", session.Client, session.Project)
+		}
+		fmt.Fprintf(&buffer, "%s for %s since %s (%s, %s %s)\n",
+			state,
+			client,
+			session.Start.Format("15:04:05"),
+			session.Duration,
+			session.Earnings,
+			session.Currency)
+		if session.Note != "" {
+			fmt.Fprintf(&buffer, "Note:  %s\n", session.Note)
+		}
+		if session.Tags != "" {
+			fmt.Fprintf(&buffer, "Tags:  %s\n", session.Tags)
+		}
+	}
+	if !status.Active {
+		buffer.WriteString("Not clocked in\n")
+	}
+	fmt.Fprintf(&buffer, "Today: %s\n", status.Today)
+	fmt.Fprintf(&buffer, "Week:  %s\n", status.Week)
+	return buffer.String()
+}
+
+func renderStatusTemplate(status *Status, format string) (string, error) {
+	tmpl, err := template.New("status").Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid format: %v", err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, status)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(buffer.String(), "\n") {
+		buffer.WriteString("\n")
+	}
+	return buffer.String(), nil
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().StringVarP(&statusFormat, "format", "f", "", "Format the output with a Go template")
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/puncher"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_GetStatus_ActiveSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+
+	now := time.Now()
+	active := createSampleSession()
+	active.Client.PPH = 100
+	active.Start = now.Add(-90 * time.Minute)
+	active.End = models.NULL_TIME
+	active.Tags = []string{"dev"}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{active}, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsBetweenDates(gomock.Any(), gomock.Any()).
+		Return(&[]models.Session{active}, nil).
+		Times(2)
+
+	status, err := getStatus(now)
+
+	assert.NoError(t, err)
+	assert.True(t, status.Active)
+	assert.Equal(t, "Test Client", status.Client)
+	assert.Equal(t, "01:30:00", status.Duration)
+	assert.Equal(t, "150.00", status.Earnings)
+	assert.Equal(t, "dev", status.Tags)
+	assert.Equal(t, "01:30:00", status.Today)
+}
+
+func TestCli_GetStatus_NoActiveSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+
+	finished := createSampleSession()
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsBetweenDates(gomock.Any(), gomock.Any()).
+		Return(&[]models.Session{finished}, nil).
+		Times(2)
+
+	status, err := getStatus(time.Now())
+
+	assert.NoError(t, err)
+	assert.False(t, status.Active)
+	assert.Equal(t, "08:00:00", status.Week)
+	assert.Contains(t, generateStatusView(status), "Not clocked in")
+}
+
+func TestCli_GetStatus_ConcurrentSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+
+	now := time.Now()
+	first := createSampleSession()
+	first.Start = now.Add(-time.Hour)
+	first.End = models.NULL_TIME
+	second := createSampleSession()
+	second.Client = models.Client{Name: "Other Client", Currency: "EUR"}
+	second.Start = now.Add(-2 * time.Hour)
+	second.End = models.NULL_TIME
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{first, second}, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsBetweenDates(gomock.Any(), gomock.Any()).
+		Return(&[]models.Session{first, second}, nil).
+		Times(2)
+
+	status, err := getStatus(now)
+
+	assert.NoError(t, err)
+	assert.Len(t, status.Sessions, 2)
+	assert.Equal(t, "Test Client", status.Client)
+	view := generateStatusView(status)
+	assert.Contains(t, view, "Clocked in for Test Client")
+	assert.Contains(t, view, "Clocked in for Other Client")
+
+	content, err := renderStatusTemplate(status, "{{range .Sessions}}{{.Client}};{{end}}")
+	assert.NoError(t, err)
+	assert.Equal(t, "Test Client;Other Client;\n", content)
+}
+
+func TestCli_RenderStatusTemplate(t *testing.T) {
+	status := &Status{
+		Active:        true,
+		StatusSession: StatusSession{Client: "Acme", Duration: "01:00:00"},
+	}
+
+	content, err := renderStatusTemplate(status, "{{.Client}} {{.Duration}}")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme 01:00:00\n", content)
+}
+
+func TestCli_RenderStatusTemplate_InvalidTemplate(t *testing.T) {
+	_, err := renderStatusTemplate(&Status{}, "{{.Client")
+	assert.ErrorContains(t, err, "invalid format")
+}
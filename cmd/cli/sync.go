@@ -3,22 +3,32 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/dormunis/punch/pkg/editor"
 	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/puncher"
+	"github.com/dormunis/punch/pkg/repositories"
 	"github.com/dormunis/punch/pkg/sync"
 	"github.com/spf13/cobra"
 )
 
 var (
 	pullOnly bool
+	syncFrom string
+	syncTo   string
 )
 
 var syncCmd = &cobra.Command{
 	Use:   "sync [remote]",
 	Short: "sync sessions with remote",
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Sync sessions with a remote. Use --from and --to to only sync the
+    sessions that started within a date range. Sessions deleted locally are
+    deleted from the remote, and sessions deleted from the remote are deleted
+    locally once confirmed.`,
+	Args: cobra.MaximumNArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		var remoteString string
 		Source = new(sync.SyncSource)
@@ -39,6 +49,7 @@ var syncCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		SourceName = remoteString
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -47,42 +58,113 @@ var syncCmd = &cobra.Command{
 }
 
 func Sync(cmd *cobra.Command) error {
-	// TODO: add delete session
-	approvedDiffs, err := pull(*Source)
+	tombstones, err := SessionRepository.GetTombstones()
 	if err != nil {
 		return err
 	}
-	if pullOnly {
-		return nil
-	}
-	newSessions, err := SessionRepository.GetAllSessionsAllClients()
+	approvedDiffs, err := pull(*Source, tombstones)
 	if err != nil {
 		return err
 	}
-	summary, err := (*Source).Push(newSessions, approvedDiffs)
+	if !pullOnly {
+		err = pushDeletions(*Source, tombstones)
+		if err != nil {
+			return err
+		}
+		newSessions, err := SessionRepository.GetAllSessionsAllClients()
+		if err != nil {
+			return err
+		}
+		newSessions, err = filterSyncRange(newSessions)
+		if err != nil {
+			return err
+		}
+		summary, err := (*Source).Push(newSessions, approvedDiffs)
+		if err != nil {
+			return err
+		}
+		if summary.Added+summary.Updated > 0 {
+			fmt.Printf("Synced %d sessions\n", summary.Added+summary.Updated)
+		}
+	}
+	err = saveSyncSnapshot(*Source)
 	if err != nil {
 		return err
 	}
-	if summary.Added+summary.Updated > 0 {
-		fmt.Printf("Synced %d sessions\n", summary.Added+summary.Updated)
+	if pullOnly {
+		return nil
 	}
-	return nil
+	return clearTombstones(*Source, tombstones)
 }
 
-func pull(source sync.SyncSource) (*[]models.Session, error) {
+// pull merges the remote sessions into the local ones, changes made on one
+// side since the last sync are taken as is, and sessions changed on both sides
+// are resolved in the editor. It returns the sessions whose local version
+// should overwrite the remote one. Sessions deleted locally are not pulled
+// back.
+func pull(source sync.SyncSource, tombstones []models.Tombstone) (*[]models.Session, error) {
 	pulledSessions, err := source.Pull()
 	if err != nil {
 		return nil, err
 	}
+	filteredPulled, err := filterSyncRange(&pulledSessions)
+	if err != nil {
+		return nil, err
+	}
 	sessions, err := SessionRepository.GetAllSessionsAllClients()
 	if err != nil {
 		return nil, err
 	}
-	conflictsBuffer, err := sync.GetConflicts(*sessions, pulledSessions)
+	sessions, err = filterSyncRange(sessions)
+	if err != nil {
+		return nil, err
+	}
+	baseSessions, err := SyncSnapshotRepository.GetAll(SourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyRemoteDeletions(baseSessions, *sessions, pulledSessions)
+	if err != nil {
+		return nil, err
+	}
+
+	result := sync.MergeThreeWay(baseSessions, *sessions, *filteredPulled)
+	for _, session := range result.Pulled {
+		err = Puncher.CheckOverlaps(session)
+		if errors.Is(err, puncher.ErrSessionOverlaps) {
+			fmt.Printf("Skipping remote session (ID: %d): %v\n", session.ID, err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = SessionRepository.Upsert(&session, false)
+		if err == repositories.ErrSessionLocked {
+			fmt.Printf("Skipping invoiced or paid session (ID: %d)\n", session.ID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Updated session %d from remote\n", session.ID)
+	}
+
+	for _, session := range result.Added {
+		if deletedLocally(session, tombstones) {
+			continue
+		}
+		err = addRemoteSession(session)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	conflictsBuffer, err := result.Conflicts.Diff()
 	if err != nil {
 		return nil, err
 	}
-	if conflictsBuffer != nil && conflictsBuffer.Len() > 0 {
+	if conflictsBuffer.Len() > 0 {
 		err = editor.InteractiveEdit(conflictsBuffer, "yaml")
 		if err != nil {
 			return nil, err
@@ -96,15 +178,275 @@ func pull(source sync.SyncSource) (*[]models.Session, error) {
 		return (*deserializedSessions)[i].Start.Before((*deserializedSessions)[j].Start)
 	})
 	for _, session := range *deserializedSessions {
+		err = Puncher.CheckOverlaps(session)
+		if errors.Is(err, puncher.ErrSessionOverlaps) {
+			fmt.Printf("Skipping session (ID: %d): %v\n", session.ID, err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
 		err = SessionRepository.Upsert(&session, false)
+		if err == repositories.ErrSessionLocked {
+			fmt.Printf("Skipping invoiced or paid session (ID: %d)\n", session.ID)
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
 	}
-	return deserializedSessions, nil
+	approvedDiffs := append(result.Resolved(), *deserializedSessions...)
+	return &approvedDiffs, nil
+}
+
+// addRemoteSession stores a session created on another device, sessions of
+// unknown clients, similar to a local session or rejected as overlapping are
+// skipped.
+func addRemoteSession(session models.Session) error {
+	client, err := ClientRepository.SafeGetByName(session.Client.Name)
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		fmt.Printf("Skipping remote session of unknown client %s (%s), add the client to pull it\n",
+			session.Client.Name, session.Start.Format("2006-01-02 15:04:05"))
+		return nil
+	}
+	session.Client = *client
+	if session.Project != nil {
+		project, err := ProjectRepository.GetByName(*client, session.Project.Name)
+		if err != nil && err != repositories.ErrProjectNotFound {
+			return err
+		}
+		session.Project = project
+	}
+
+	err = Puncher.CheckOverlaps(session)
+	if errors.Is(err, puncher.ErrSessionOverlaps) {
+		fmt.Printf("Skipping remote session (UUID: %s): %v\n", session.UUID, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = SessionRepository.Insert(&session, false)
+	if errors.Is(err, repositories.ErrConflictingIds) || errors.Is(err, repositories.ErrInfoConflict) {
+		fmt.Printf("Skipping remote session (UUID: %s): %v\n", session.UUID, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Added session of %s (%s) from remote\n", session.Client.Name, session.Start.Format("2006-01-02 15:04:05"))
+	return nil
+}
+
+// applyRemoteDeletions deletes the local sessions that were deleted from the
+// remote since the last sync, once confirmed. Sessions that are kept are
+// pushed to the remote again.
+func applyRemoteDeletions(baseSessions, localSessions, remoteSessions []models.Session) error {
+	deleted, err := missingSessions(baseSessions, remoteSessions)
+	if err != nil {
+		return err
+	}
+	var sessions []models.Session
+	for _, session := range localSessions {
+		if deleted[session.ID] {
+			sessions = append(sessions, session)
+		}
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	for _, session := range sessions {
+		fmt.Printf("Session %d was deleted from remote: %s\n", session.ID, session.String())
+	}
+	if !confirmRemoteDeletion() {
+		fmt.Println("Keeping the sessions, they will be pushed to remote again")
+		return nil
+	}
+	for _, session := range sessions {
+		err := SessionRepository.Delete(&session, false)
+		if err == repositories.ErrSessionLocked {
+			fmt.Printf("Skipping invoiced or paid session (ID: %d)\n", session.ID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deleted session %d\n", session.ID)
+	}
+	return nil
+}
+
+func confirmRemoteDeletion() bool {
+	if approveDelete {
+		return true
+	}
+
+	fmt.Print("Are you sure you want to delete these sessions locally (y/n)? ")
+	var answer string
+	decision, err := fmt.Scanln(&answer)
+	if decision != 1 || err != nil {
+		return false
+	}
+	return strings.ToLower(answer) == "y"
+}
+
+// pushDeletions deletes the sessions that were deleted locally from the
+// remote, according to their tombstones.
+func pushDeletions(source sync.SyncSource, tombstones []models.Tombstone) error {
+	if len(tombstones) == 0 {
+		return nil
+	}
+	remoteSessions, err := source.Pull()
+	if err != nil {
+		return err
+	}
+	filteredRemote, err := filterSyncRange(&remoteSessions)
+	if err != nil {
+		return err
+	}
+
+	var sessions []models.Session
+	for _, session := range *filteredRemote {
+		if deletedLocally(session, tombstones) {
+			sessions = append(sessions, session)
+		}
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	summary, err := source.Delete(&sessions)
+	if err != nil {
+		return err
+	}
+	if summary.Deleted > 0 {
+		fmt.Printf("Deleted %d sessions from remote\n", summary.Deleted)
+	}
+	return nil
+}
+
+// clearTombstones forgets the tombstones of the sessions the remote no longer
+// holds, unless the last sync with another remote saw them there.
+func clearTombstones(source sync.SyncSource, tombstones []models.Tombstone) error {
+	if len(tombstones) == 0 {
+		return nil
+	}
+	remoteSessions, err := source.Pull()
+	if err != nil {
+		return err
+	}
+	held := make(map[uint32]bool)
+	for name := range Config.Remotes {
+		if name == SourceName {
+			continue
+		}
+		snapshot, err := SyncSnapshotRepository.GetAll(name)
+		if err != nil {
+			return err
+		}
+		for _, session := range snapshot {
+			held[session.ID] = true
+		}
+	}
+
+	var cleared []uint32
+	for _, tombstone := range tombstones {
+		if held[tombstone.SessionID] || slices.ContainsFunc(remoteSessions, tombstone.Matches) {
+			continue
+		}
+		cleared = append(cleared, tombstone.SessionID)
+	}
+	if len(cleared) == 0 {
+		return nil
+	}
+	return SessionRepository.DeleteTombstones(cleared)
+}
+
+func deletedLocally(session models.Session, tombstones []models.Tombstone) bool {
+	for _, tombstone := range tombstones {
+		if tombstone.Matches(session) {
+			return true
+		}
+	}
+	return false
+}
+
+// saveSyncSnapshot stores the sessions the remote holds after the sync, as
+// the base of the next sync's merge, and forgets the ones it no longer holds.
+func saveSyncSnapshot(source sync.SyncSource) error {
+	remoteSessions, err := source.Pull()
+	if err != nil {
+		return err
+	}
+	filteredRemote, err := filterSyncRange(&remoteSessions)
+	if err != nil {
+		return err
+	}
+	err = SyncSnapshotRepository.Save(SourceName, *filteredRemote)
+	if err != nil {
+		return err
+	}
+
+	baseSessions, err := SyncSnapshotRepository.GetAll(SourceName)
+	if err != nil {
+		return err
+	}
+	missing, err := missingSessions(baseSessions, remoteSessions)
+	if err != nil {
+		return err
+	}
+	var removedIDs []uint32
+	for id := range missing {
+		removedIDs = append(removedIDs, id)
+	}
+	if len(removedIDs) == 0 {
+		return nil
+	}
+	sort.Slice(removedIDs, func(i, j int) bool { return removedIDs[i] < removedIDs[j] })
+	return SyncSnapshotRepository.Delete(SourceName, removedIDs)
+}
+
+// missingSessions returns the IDs of the base sessions within the sync range
+// that the remote no longer holds.
+func missingSessions(baseSessions, remoteSessions []models.Session) (map[uint32]bool, error) {
+	remote := make(map[uint32]bool)
+	for _, session := range remoteSessions {
+		remote[session.ID] = true
+	}
+	filteredBase, err := filterSyncRange(&baseSessions)
+	if err != nil {
+		return nil, err
+	}
+	missing := make(map[uint32]bool)
+	for _, session := range *filteredBase {
+		if !remote[session.ID] {
+			missing[session.ID] = true
+		}
+	}
+	return missing, nil
+}
+
+// filterSyncRange keeps the sessions within --from and --to, all sessions are
+// synced when neither is given.
+func filterSyncRange(sessions *[]models.Session) (*[]models.Session, error) {
+	if syncFrom == "" && syncTo == "" {
+		return sessions, nil
+	}
+	start, end, err := ParseDateRange(syncFrom, syncTo, nil)
+	if err != nil {
+		return nil, err
+	}
+	return FilterSessionsByRange(sessions, start, end), nil
 }
 
 func init() {
 	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().BoolVarP(&approveDelete, "yes", "y", false, "Approve deletion of sessions deleted from remote automatically")
 	syncCmd.Flags().BoolVar(&pullOnly, "pull-only", false, "Only pull sessions from remote")
+	syncCmd.Flags().StringVar(&syncFrom, "from", "", "Only sync sessions from a specific date (e.g. 2026-09-01)")
+	syncCmd.Flags().StringVar(&syncTo, "to", "", "Only sync sessions up to a specific date, which is included entirely (e.g. 2026-09-15)")
 }
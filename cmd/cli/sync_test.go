@@ -0,0 +1,451 @@
+package cli
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/config"
+	"github.com/dormunis/punch/pkg/database"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/puncher"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/dormunis/punch/pkg/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSyncSource struct {
+	sessions []models.Session
+	pushed   []models.Session
+	approved []models.Session
+	deleted  []models.Session
+}
+
+func (f *fakeSyncSource) Type() string {
+	return "fake"
+}
+
+func (f *fakeSyncSource) Pull() ([]models.Session, error) {
+	return f.sessions, nil
+}
+
+func (f *fakeSyncSource) Push(sessions *[]models.Session, approvedDiffs *[]models.Session) (sync.PushSummary, error) {
+	f.pushed = *sessions
+	f.approved = *approvedDiffs
+	return sync.PushSummary{}, nil
+}
+
+func (f *fakeSyncSource) Delete(sessions *[]models.Session) (sync.PushSummary, error) {
+	f.deleted = *sessions
+	var remaining []models.Session
+	for _, session := range f.sessions {
+		if !slices.ContainsFunc(*sessions, func(deleted models.Session) bool { return deleted.ID == session.ID }) {
+			remaining = append(remaining, session)
+		}
+	}
+	f.sessions = remaining
+	return sync.PushSummary{Deleted: len(*sessions)}, nil
+}
+
+func TestCli_Sync_MergesOneSidedChangesWithoutEditor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+	SyncSnapshotRepository = repositories.NewMockSyncSnapshotRepository(mockCtrl)
+	SourceName = "origin"
+	defer func() { SourceName = "" }()
+
+	base := createSampleSession()
+	base.Client = models.Client{Name: base.Client.Name}
+	local := base
+	local.Note = "changed locally"
+	remote := base
+	remote.End = base.End.Add(time.Hour)
+	merged := local
+	merged.End = remote.End
+	source := &fakeSyncSource{sessions: []models.Session{remote}}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsAllClients().
+		Return(&[]models.Session{local}, nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("origin").
+		Return([]models.Session{base}, nil).
+		Times(2)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetTombstones().
+		Return(nil, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		Upsert(&merged, false).
+		Return(nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsAllClients().
+		Return(&[]models.Session{merged}, nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		Save("origin", []models.Session{remote}).
+		Return(nil).
+		Times(1)
+
+	var syncSource sync.SyncSource = source
+	Source = &syncSource
+	defer func() { Source = nil }()
+
+	err := Sync(rootCmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Session{merged}, source.pushed)
+	assert.Equal(t, []models.Session{merged}, source.approved)
+}
+
+func TestCli_Sync_PushesLocalDeletions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+	SyncSnapshotRepository = repositories.NewMockSyncSnapshotRepository(mockCtrl)
+	SourceName = "origin"
+	defer func() { SourceName = "" }()
+	defer func(previous *config.Config) { Config = previous }(Config)
+	Config = &config.Config{Remotes: map[string]config.Remote{"origin": nil}}
+
+	kept := createSampleSession()
+	deleted := createSampleSession()
+	deleted.ID = kept.ID + 1
+	source := &fakeSyncSource{sessions: []models.Session{kept, deleted}}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsAllClients().
+		Return(&[]models.Session{kept}, nil).
+		Times(2)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("origin").
+		Return(nil, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetTombstones().
+		Return([]models.Tombstone{{SessionID: deleted.ID, DeletedAt: time.Now()}}, nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		Save("origin", []models.Session{kept}).
+		Return(nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("origin").
+		Return([]models.Session{kept, deleted}, nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		Delete("origin", []uint32{deleted.ID}).
+		Return(nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		DeleteTombstones([]uint32{deleted.ID}).
+		Return(nil).
+		Times(1)
+
+	var syncSource sync.SyncSource = source
+	Source = &syncSource
+	defer func() { Source = nil }()
+
+	err := Sync(rootCmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Session{deleted}, source.deleted)
+	assert.Equal(t, []models.Session{kept}, source.sessions)
+}
+
+func TestCli_Sync_PushesLocalDeletionsByUUID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+	SyncSnapshotRepository = repositories.NewMockSyncSnapshotRepository(mockCtrl)
+	SourceName = "origin"
+	defer func() { SourceName = "" }()
+	defer func(previous *config.Config) { Config = previous }(Config)
+	Config = &config.Config{Remotes: map[string]config.Remote{"origin": nil, "backup": nil}}
+
+	deleted := createSampleSession()
+	deleted.ID = 0
+	deleted.UUID = "deleted-uuid"
+	colliding := createSampleSession()
+	colliding.UUID = "another-device-uuid"
+	colliding.Start = colliding.Start.AddDate(0, 0, -1)
+	source := &fakeSyncSource{sessions: []models.Session{deleted, colliding}}
+	tombstone := models.Tombstone{SessionID: colliding.ID, SessionUUID: "deleted-uuid", DeletedAt: time.Now()}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsAllClients().
+		Return(&[]models.Session{}, nil).
+		Times(2)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("origin").
+		Return(nil, nil).
+		Times(2)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetTombstones().
+		Return([]models.Tombstone{tombstone}, nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		Save("origin", []models.Session{colliding}).
+		Return(nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("backup").
+		Return(nil, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		DeleteTombstones([]uint32{colliding.ID}).
+		Return(nil).
+		Times(1)
+
+	var syncSource sync.SyncSource = source
+	Source = &syncSource
+	defer func() { Source = nil }()
+
+	err := Sync(rootCmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Session{deleted}, source.deleted)
+	assert.Equal(t, []models.Session{colliding}, source.sessions)
+}
+
+func TestCli_Sync_KeepsTombstonesHeldByAnotherRemote(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+	SyncSnapshotRepository = repositories.NewMockSyncSnapshotRepository(mockCtrl)
+	SourceName = "origin"
+	defer func() { SourceName = "" }()
+	defer func(previous *config.Config) { Config = previous }(Config)
+	Config = &config.Config{Remotes: map[string]config.Remote{"origin": nil, "backup": nil}}
+
+	deleted := createSampleSession()
+	source := &fakeSyncSource{}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsAllClients().
+		Return(&[]models.Session{}, nil).
+		Times(2)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("origin").
+		Return(nil, nil).
+		Times(2)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetTombstones().
+		Return([]models.Tombstone{{SessionID: deleted.ID, DeletedAt: time.Now()}}, nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		Save("origin", gomock.Len(0)).
+		Return(nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("backup").
+		Return([]models.Session{deleted}, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		DeleteTombstones(gomock.Any()).
+		Times(0)
+
+	var syncSource sync.SyncSource = source
+	Source = &syncSource
+	defer func() { Source = nil }()
+
+	err := Sync(rootCmd)
+
+	assert.NoError(t, err)
+	assert.Empty(t, source.deleted)
+}
+
+func TestCli_Sync_AppliesRemoteDeletions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+	SyncSnapshotRepository = repositories.NewMockSyncSnapshotRepository(mockCtrl)
+	SourceName = "origin"
+	approveDelete = true
+	pullOnly = true
+	defer func() {
+		SourceName = ""
+		approveDelete = false
+		pullOnly = false
+	}()
+
+	kept := createSampleSession()
+	kept.Client = models.Client{Name: kept.Client.Name}
+	deleted := kept
+	deleted.ID = kept.ID + 1
+	source := &fakeSyncSource{sessions: []models.Session{kept}}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetTombstones().
+		Return(nil, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsAllClients().
+		Return(&[]models.Session{kept, deleted}, nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("origin").
+		Return([]models.Session{kept, deleted}, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		Delete(&deleted, false).
+		Return(nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		Save("origin", []models.Session{kept}).
+		Return(nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("origin").
+		Return([]models.Session{kept, deleted}, nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		Delete("origin", []uint32{deleted.ID}).
+		Return(nil).
+		Times(1)
+
+	var syncSource sync.SyncSource = source
+	Source = &syncSource
+	defer func() { Source = nil }()
+
+	err := Sync(rootCmd)
+
+	assert.NoError(t, err)
+	assert.Empty(t, source.deleted)
+}
+
+func TestCli_Sync_SkipsRemoteChangesRejectedAsOverlapping(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	SessionRepository = repositories.NewMockSessionRepository(mockCtrl)
+	Puncher = puncher.NewPuncher(SessionRepository)
+	Puncher.OverlapPolicy = puncher.OVERLAP_POLICY_REJECT
+	SyncSnapshotRepository = repositories.NewMockSyncSnapshotRepository(mockCtrl)
+	SourceName = "origin"
+	pullOnly = true
+	defer func() {
+		SourceName = ""
+		pullOnly = false
+	}()
+
+	other := createSampleSession()
+	other.Client = models.Client{Name: other.Client.Name}
+	base := other
+	base.ID = other.ID + 1
+	base.Start = other.End.Add(time.Hour)
+	base.End = base.Start.Add(time.Hour)
+	remote := base
+	remote.Start = other.End.Add(-time.Hour)
+	source := &fakeSyncSource{sessions: []models.Session{other, remote}}
+
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetTombstones().
+		Return(nil, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetAllSessionsAllClients().
+		Return(&[]models.Session{other, base}, nil).
+		Times(1)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		GetAll("origin").
+		Return([]models.Session{other, base}, nil).
+		Times(2)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		GetOverlappingSessions(remote, nil).
+		Return(&[]models.Session{other}, nil).
+		Times(1)
+	SessionRepository.(*repositories.MockSessionRepository).EXPECT().
+		Upsert(gomock.Any(), gomock.Any()).
+		Times(0)
+	SyncSnapshotRepository.(*repositories.MockSyncSnapshotRepository).EXPECT().
+		Save("origin", []models.Session{other, remote}).
+		Return(nil).
+		Times(1)
+
+	var syncSource sync.SyncSource = source
+	Source = &syncSource
+	defer func() { Source = nil }()
+
+	err := Sync(rootCmd)
+
+	assert.NoError(t, err)
+}
+
+// newDevice opens a database of its own, the returned function points the
+// commands at it as a new run of punch would, synced with the shared remote.
+func newDevice(t *testing.T, name string, remote *config.FileRemote) func() {
+	db, err := database.NewDatabase("sqlite3", filepath.Join(t.TempDir(), name+".db"))
+	assert.NoError(t, err)
+	return func() {
+		SessionRepository = repositories.NewGORMSessionRepository(db)
+		ClientRepository = repositories.NewGORMClientRepository(db)
+		ProjectRepository = repositories.NewGORMProjectRepository(db)
+		SyncSnapshotRepository = repositories.NewGORMSyncSnapshotRepository(db)
+		Puncher = puncher.NewPuncher(SessionRepository)
+		source, err := sync.NewSource(remote, SessionRepository)
+		assert.NoError(t, err)
+		Source = &source
+	}
+}
+
+func TestCli_Sync_PullsSessionsOfAnotherDevice(t *testing.T) {
+	remote := &config.FileRemote{Path: filepath.Join(t.TempDir(), "sessions.json"), Format: "json"}
+	SourceName = "shared"
+	defer func() { SourceName, Source = "", nil }()
+	defer func(previous *config.Config) { Config = previous }(Config)
+	Config = &config.Config{Remotes: map[string]config.Remote{"shared": remote}}
+
+	client := models.Client{Name: "Acme", PPH: 100, Currency: "USD"}
+	start := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.Local)
+	deviceSession := func(day int) models.Session {
+		dayStart := start.AddDate(0, 0, day)
+		return models.Session{Client: client, Start: dayStart, End: dayStart.Add(time.Hour), Note: "device"}
+	}
+	sessionsOf := func() []models.Session {
+		sessions, err := SessionRepository.GetAllSessionsAllClients()
+		assert.NoError(t, err)
+		return *sessions
+	}
+	deviceA := newDevice(t, "a", remote)
+	deviceB := newDevice(t, "b", remote)
+
+	deviceA()
+	assert.NoError(t, ClientRepository.Insert(&client))
+	first := deviceSession(0)
+	assert.NoError(t, SessionRepository.Insert(&first, false))
+	assert.NoError(t, Sync(rootCmd))
+
+	deviceB()
+	assert.NoError(t, ClientRepository.Insert(&client))
+	second := deviceSession(1)
+	assert.NoError(t, SessionRepository.Insert(&second, false))
+	assert.NoError(t, Sync(rootCmd))
+	pulled := sessionsOf()
+	assert.Len(t, pulled, 2, "device B pulls the session of device A despite its colliding ID")
+
+	deviceB()
+	assert.NoError(t, Sync(rootCmd))
+	assert.Len(t, sessionsOf(), 2, "a second sync adds nothing")
+	remoteSessions, err := (*Source).Pull()
+	assert.NoError(t, err)
+	assert.Len(t, remoteSessions, 2)
+
+	deviceA()
+	assert.NoError(t, Sync(rootCmd))
+	synced := sessionsOf()
+	assert.Len(t, synced, 2, "device A pulls the session of device B")
+	for _, session := range pulled {
+		assert.True(t, slices.ContainsFunc(synced, func(s models.Session) bool { return s.UUID == session.UUID }),
+			"session %s is not synced by UUID", session.UUID)
+	}
+}
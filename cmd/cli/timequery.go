@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -14,29 +15,248 @@ func ExtractTime(input string, client *models.Client) (time.Time, time.Time, err
 	var parsedTime time.Time
 	var err error
 
+	if from, to, found := strings.Cut(input, rangeSeparator); found {
+		return ParseDateRange(from, to, client)
+	}
+
 	if strings.HasPrefix(input, "-") {
 		return extractRelativeTime(input, client)
 	}
 
-	parsedTime, err = time.Parse("2006", input)
+	parsedTime, err = time.ParseInLocation("2006", input, time.Local)
 	if err == nil {
-		startOfNextYear := parsedTime.AddDate(1, 0, 0).Truncate(24 * time.Hour)
-		return parsedTime, startOfNextYear, nil
+		return parsedTime, parsedTime.AddDate(1, 0, 0), nil
 	}
 
-	parsedTime, err = time.Parse("2006-01", input)
+	parsedTime, err = time.ParseInLocation("2006-01", input, time.Local)
 	if err == nil {
-		startOfNextMonth := parsedTime.AddDate(0, 1, 0).Truncate(24 * time.Hour)
-		return parsedTime, startOfNextMonth, nil
+		return parsedTime, parsedTime.AddDate(0, 1, 0), nil
+	}
+
+	if match := isoWeekPattern.FindStringSubmatch(strings.ToUpper(input)); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		week, _ := strconv.Atoi(match[2])
+		parsedTime, err = isoWeekStart(year, week, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return parsedTime, parsedTime.AddDate(0, 0, 7), nil
 	}
 
-	parsedTime, err = time.Parse("2006-01-02", input)
+	parsedTime, err = time.ParseInLocation("2006-01-02", input, time.Local)
 	if err == nil {
-		startOfNextDay := parsedTime.Add(24 * time.Hour).Truncate(24 * time.Hour)
-		return parsedTime, startOfNextDay, nil
+		return parsedTime, parsedTime.AddDate(0, 0, 1), nil
+	}
+
+	return ParseTimeExpression(input, time.Now())
+}
+
+const rangeSeparator = ".."
+
+var (
+	clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+
+	weekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "sun": time.Sunday,
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+	}
+
+	isoLayouts = []string{
+		"2006-01-02T15:04",
+		"2006-01-02T15:04:05",
+	}
+)
+
+// ParseTimeExpression parses a day and/or a clock time relative to now, e.g.
+// `09:15`, `at 9am`, `yesterday 18:30`, `last friday 14:00` or
+// `2026-10-01T09:00`. A day without a clock time spans the whole day, any
+// other expression is a point in time until now.
+func ParseTimeExpression(input string, now time.Time) (time.Time, time.Time, error) {
+	errInvalid := fmt.Errorf("invalid time format")
+	location := now.Location()
+
+	var day *time.Time
+	var clock *time.Duration
+	setDay := func(t time.Time) bool {
+		if day != nil {
+			return false
+		}
+		startOfDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+		day = &startOfDay
+		return true
+	}
+	setClock := func(d time.Duration) bool {
+		if clock != nil {
+			return false
+		}
+		clock = &d
+		return true
+	}
+
+	tokens := strings.Fields(strings.ToLower(input))
+	if len(tokens) == 0 {
+		return time.Time{}, time.Time{}, errInvalid
+	}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		ok := true
+		switch {
+		case token == "at":
+			ok = i+1 < len(tokens)
+		case token == "today":
+			ok = setDay(now)
+		case token == "yesterday":
+			ok = setDay(now.AddDate(0, 0, -1))
+		case token == "tomorrow":
+			ok = setDay(now.AddDate(0, 0, 1))
+		case token == "last":
+			if i+1 >= len(tokens) {
+				return time.Time{}, time.Time{}, errInvalid
+			}
+			weekday, found := weekdays[tokens[i+1]]
+			ok = found && setDay(previousWeekday(now.AddDate(0, 0, -1), weekday))
+			i++
+		case token == "noon":
+			ok = setClock(12 * time.Hour)
+		case token == "midnight":
+			ok = setClock(0)
+		default:
+			if weekday, found := weekdays[token]; found {
+				ok = setDay(previousWeekday(now, weekday))
+			} else if offset, found := parseClock(token); found {
+				ok = setClock(offset)
+			} else if date, err := time.ParseInLocation("2006-01-02", token, location); err == nil {
+				ok = setDay(date)
+			} else if datetime, found := parseISOTime(token, location); found {
+				ok = setDay(datetime) && setClock(clockOf(datetime))
+			} else {
+				ok = false
+			}
+		}
+		if !ok {
+			return time.Time{}, time.Time{}, errInvalid
+		}
+	}
+
+	if clock == nil {
+		if day == nil {
+			return time.Time{}, time.Time{}, errInvalid
+		}
+		return *day, day.AddDate(0, 0, 1), nil
+	}
+	if day == nil {
+		setDay(now)
 	}
+	parsedTime := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, int(clock.Seconds()), 0, location)
+	return parsedTime, now, nil
+}
+
+// ParseDateRange parses the bounds of an explicit range such as
+// `2026-09-01..2026-09-15`. A day, month or year given as the upper bound is
+// included entirely, an empty bound leaves that side of the range open.
+func ParseDateRange(from string, to string, client *models.Client) (time.Time, time.Time, error) {
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" && to == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range, at least one bound is required")
+	}
+
+	var start time.Time
+	end := time.Now()
+	var err error
+	if from != "" {
+		start, _, err = ExtractTime(from, client)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid range start: %v", err)
+		}
+	}
+	if to != "" {
+		var periodStart, periodEnd time.Time
+		periodStart, periodEnd, err = ExtractTime(to, client)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid range end: %v", err)
+		}
+		end = periodStart
+		if isStartOfDay(periodEnd) {
+			end = periodEnd
+		}
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range, start must be before end")
+	}
+	return start, end, nil
+}
 
-	return time.Time{}, time.Time{}, fmt.Errorf("invalid time format")
+// isStartOfDay reports whether t is exactly midnight, which is how the end of
+// a whole day, month or year is expressed.
+func isStartOfDay(t time.Time) bool {
+	hour, minute, second := t.Clock()
+	return hour == 0 && minute == 0 && second == 0 && t.Nanosecond() == 0
+}
+
+// clockOf returns the wall clock time of t as an offset from midnight.
+func clockOf(t time.Time) time.Duration {
+	hour, minute, second := t.Clock()
+	return time.Duration(hour)*time.Hour +
+		time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second
+}
+
+// previousWeekday returns the latest given weekday up until the given day.
+func previousWeekday(from time.Time, weekday time.Weekday) time.Time {
+	delta := (int(from.Weekday()) - int(weekday) + 7) % 7
+	return from.AddDate(0, 0, -delta)
+}
+
+// parseClock parses a 24-hour (`14:00`, `09:15:30`) or 12-hour (`9am`,
+// `9:30pm`) clock time into an offset from midnight.
+func parseClock(token string) (time.Duration, bool) {
+	match := clockPattern.FindStringSubmatch(token)
+	if match == nil || (match[2] == "" && match[4] == "") {
+		return 0, false
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	second, _ := strconv.Atoi(match[3])
+	if minute > 59 || second > 59 {
+		return 0, false
+	}
+	switch match[4] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, false
+		}
+		hour %= 12
+		if match[4] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, false
+		}
+	}
+	return time.Duration(hour)*time.Hour +
+		time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second, true
+}
+
+func parseISOTime(token string, location *time.Location) (time.Time, bool) {
+	token = strings.ToUpper(token)
+	for _, layout := range isoLayouts {
+		parsedTime, err := time.ParseInLocation(layout, token, location)
+		if err == nil {
+			return parsedTime, true
+		}
+	}
+	parsedTime, err := time.Parse(time.RFC3339, token)
+	if err == nil {
+		return parsedTime.In(location), true
+	}
+	return time.Time{}, false
 }
 
 func extractRelativeTime(input string, client *models.Client) (time.Time, time.Time, error) {
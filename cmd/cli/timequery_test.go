@@ -10,6 +10,17 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// pinLocation sets the local time zone for the duration of the test.
+func pinLocation(t *testing.T, name string) {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s is not available: %v", name, err)
+	}
+	previous := time.Local
+	time.Local = location
+	t.Cleanup(func() { time.Local = previous })
+}
+
 func calculateDelta(start, end time.Time) time.Duration {
 	delta := end.Sub(start)
 	delta = delta - (delta % time.Second) // truncate milliseconds
@@ -174,3 +185,194 @@ func TestTimeQuery_ExtractTime_InvalidTime(t *testing.T) {
 	_, _, err := ExtractTime("2", &client)
 	assert.Error(t, err)
 }
+
+func TestTimeQuery_ParseTimeExpression(t *testing.T) {
+	now := time.Date(2026, time.October, 14, 14, 30, 45, 0, time.UTC) // a Wednesday
+	at := func(day int, hour int, minute int, second int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, second, 0, time.UTC)
+	}
+
+	tests := []struct {
+		input string
+		start time.Time
+		end   time.Time
+	}{
+		// clock times
+		{"09:15", at(14, 9, 15, 0), now},
+		{"9:15", at(14, 9, 15, 0), now},
+		{"09:15:30", at(14, 9, 15, 30), now},
+		{"00:00", at(14, 0, 0, 0), now},
+		{"23:59", at(14, 23, 59, 0), now},
+		{"9am", at(14, 9, 0, 0), now},
+		{"9AM", at(14, 9, 0, 0), now},
+		{"9:30pm", at(14, 21, 30, 0), now},
+		{"12am", at(14, 0, 0, 0), now},
+		{"12pm", at(14, 12, 0, 0), now},
+		{"noon", at(14, 12, 0, 0), now},
+		{"midnight", at(14, 0, 0, 0), now},
+		{"at 9am", at(14, 9, 0, 0), now},
+		{"at 18:30", at(14, 18, 30, 0), now},
+
+		// days
+		{"today", at(14, 0, 0, 0), at(15, 0, 0, 0)},
+		{"yesterday", at(13, 0, 0, 0), at(14, 0, 0, 0)},
+		{"tomorrow", at(15, 0, 0, 0), at(16, 0, 0, 0)},
+		{"monday", at(12, 0, 0, 0), at(13, 0, 0, 0)},
+		{"Mon", at(12, 0, 0, 0), at(13, 0, 0, 0)},
+		{"wednesday", at(14, 0, 0, 0), at(15, 0, 0, 0)},
+		{"thursday", at(8, 0, 0, 0), at(9, 0, 0, 0)},
+		{"last wednesday", at(7, 0, 0, 0), at(8, 0, 0, 0)},
+		{"last friday", at(9, 0, 0, 0), at(10, 0, 0, 0)},
+		{"last tue", at(13, 0, 0, 0), at(14, 0, 0, 0)},
+
+		// days with clock times
+		{"yesterday 18:30", at(13, 18, 30, 0), now},
+		{"yesterday at 6pm", at(13, 18, 0, 0), now},
+		{"18:30 yesterday", at(13, 18, 30, 0), now},
+		{"today 08:00", at(14, 8, 0, 0), now},
+		{"monday 9am", at(12, 9, 0, 0), now},
+		{"last friday 14:00", at(9, 14, 0, 0), now},
+		{"last friday at 2pm", at(9, 14, 0, 0), now},
+		{"2026-10-01 09:00", at(1, 9, 0, 0), now},
+		{"at 09:00 2026-10-01", at(1, 9, 0, 0), now},
+
+		// ISO-8601
+		{"2026-10-01T09:00", at(1, 9, 0, 0), now},
+		{"2026-10-01T09:00:30", at(1, 9, 0, 30), now},
+		{"2026-10-01T09:00:00Z", at(1, 9, 0, 0), now},
+		{"2026-10-01T11:00:00+02:00", at(1, 9, 0, 0), now},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			start, end, err := ParseTimeExpression(test.input, now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.start, start)
+			assert.Equal(t, test.end, end)
+		})
+	}
+}
+
+func TestTimeQuery_ParseTimeExpression_Invalid(t *testing.T) {
+	now := time.Date(2026, time.October, 14, 14, 30, 45, 0, time.UTC)
+
+	inputs := []string{
+		"",
+		"2",
+		"9",
+		"24:00",
+		"09:60",
+		"09:15:60",
+		"13pm",
+		"0am",
+		"at",
+		"last",
+		"last week",
+		"last 9am",
+		"someday",
+		"today yesterday",
+		"monday friday",
+		"9am 10am",
+		"noon 12:00",
+		"2026-10-01T09:00 10:00",
+		"yesterday 18:30 extra",
+		"2026-13-01T09:00",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, _, err := ParseTimeExpression(input, now)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTimeQuery_ExtractTime_FallsBackToTimeExpression(t *testing.T) {
+	client := models.Client{Name: "test"}
+
+	start, end, err := ExtractTime("yesterday", &client)
+
+	assert.NoError(t, err)
+	today := time.Now()
+	yesterday := time.Date(today.Year(), today.Month(), today.Day()-1, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, yesterday, start)
+	assert.Equal(t, yesterday.AddDate(0, 0, 1), end)
+}
+
+func TestTimeQuery_ParseDateRange(t *testing.T) {
+	start, end, err := ParseDateRange("2026-09-01", "2026-09-15", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.September, 1, 0, 0, 0, 0, time.Local), start)
+	assert.Equal(t, time.Date(2026, time.September, 16, 0, 0, 0, 0, time.Local), end)
+}
+
+func TestTimeQuery_ParseDateRange_DatesAreLocal(t *testing.T) {
+	pinLocation(t, "America/New_York")
+
+	start, end, err := ParseDateRange("2026-09-01", "2026-09-30", nil)
+	assert.NoError(t, err)
+	monthStart, monthEnd, err := parseInvoiceMonth("2026-09")
+	assert.NoError(t, err)
+
+	assert.True(t, monthStart.Equal(start), "%v is not the start of the month", start)
+	assert.True(t, monthEnd.Equal(end), "%v is not the end of the month", end)
+
+	start, end, err = ExtractTime("2026-09", nil)
+	assert.NoError(t, err)
+	assert.True(t, monthStart.Equal(start))
+	assert.True(t, monthEnd.Equal(end))
+}
+
+func TestTimeQuery_ParseDateRange_UpperBoundTimeIsExclusive(t *testing.T) {
+	start, end, err := ParseDateRange("2026-09-01T09:00", "2026-09-01T17:30", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.September, 1, 9, 0, 0, 0, time.Local), start)
+	assert.Equal(t, time.Date(2026, time.September, 1, 17, 30, 0, 0, time.Local), end)
+}
+
+func TestTimeQuery_ParseDateRange_OpenEnded(t *testing.T) {
+	start, end, err := ParseDateRange("", "2026-09", nil)
+	assert.NoError(t, err)
+	assert.True(t, start.IsZero())
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local), end)
+
+	before := time.Now()
+	start, end, err = ParseDateRange("2026-09-01", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.September, 1, 0, 0, 0, 0, time.Local), start)
+	assert.False(t, end.Before(before))
+}
+
+func TestTimeQuery_ParseDateRange_Invalid(t *testing.T) {
+	ranges := [][2]string{
+		{"", ""},
+		{"not a date", "2026-09-15"},
+		{"2026-09-01", "not a date"},
+		{"2026-09-15", "2026-09-01"},
+	}
+
+	for _, r := range ranges {
+		t.Run(r[0]+".."+r[1], func(t *testing.T) {
+			_, _, err := ParseDateRange(r[0], r[1], nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTimeQuery_ExtractTime_RangeShorthand(t *testing.T) {
+	start, end, err := ExtractTime("2026-09-01..2026-09-15", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.September, 1, 0, 0, 0, 0, time.Local), start)
+	assert.Equal(t, time.Date(2026, time.September, 16, 0, 0, 0, 0, time.Local), end)
+}
+
+func TestTimeQuery_ExtractTime_ISOWeek(t *testing.T) {
+	start, end, err := ExtractTime("2026-W40", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, time.September, 28, 0, 0, 0, 0, time.Local), start)
+	assert.Equal(t, time.Date(2026, time.October, 5, 0, 0, 0, 0, time.Local), end)
+}
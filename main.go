@@ -4,6 +4,7 @@ Copyright © 2023 Dor Munis <dormunis@gmail.com>
 package main
 
 import (
+	"errors"
 	"github.com/dormunis/punch/cmd/cli"
 	"github.com/dormunis/punch/pkg/config"
 	"log"
@@ -17,6 +18,10 @@ func main() {
 		os.Exit(1)
 	}
 	err = cli.Execute(cfg)
+	if errors.Is(err, cli.ErrNoActiveSession) {
+		// the status was already printed, only the exit code matters
+		os.Exit(1)
+	}
 	if err != nil {
 		log.Fatalf("%v", err)
 		os.Exit(1)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-playground/validator"
 	"github.com/spf13/viper"
@@ -12,6 +13,7 @@ import (
 type Config struct {
 	Settings Settings
 	Database Database
+	Import   Import
 	Remotes  map[string]Remote
 }
 
@@ -21,6 +23,18 @@ type Settings struct {
 	DefaultRemote string   `mapstructure:"default_remote"`
 	DefaultClient string   `mapstructure:"default_client"` // TODO: this might be better as a databased setting
 	AutoSync      []string `mapstructure:"autosync" validate:"omitempty,dive,oneof=start end edit delete"`
+	// ConcurrentSessions allows one open session per client, otherwise only a
+	// single session can be open at a time.
+	ConcurrentSessions bool `mapstructure:"concurrent_sessions"`
+	// Overlaps decides whether sessions overlapping others are allowed,
+	// warned about or rejected.
+	Overlaps string `mapstructure:"overlaps" validate:"omitempty,oneof=allow warn reject"`
+	// WeekStart is the first day of the week used by weekly reports.
+	WeekStart string `mapstructure:"week_start" validate:"omitempty,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+	// ReportingCurrency is the currency summaries and invoices convert their
+	// totals to using the exchange rate table, totals are not converted when
+	// it is unset.
+	ReportingCurrency string `mapstructure:"reporting_currency" validate:"omitempty,len=3"`
 }
 
 type Database struct {
@@ -28,6 +42,31 @@ type Database struct {
 	Path   string `validate:"required"`
 }
 
+type Import struct {
+	// DefaultPPH is the hourly rate of clients created by an import.
+	DefaultPPH uint16    `mapstructure:"default_pph"`
+	CSV        CSVImport `mapstructure:"csv"`
+}
+
+// CSVImport maps CSV header names to session fields for `punch import csv`,
+// unset columns default to the layout of `punch get session -o csv -v`.
+type CSVImport struct {
+	DateFormat string `mapstructure:"date_format"`
+	TimeFormat string `mapstructure:"time_format"`
+	Columns    struct {
+		ID        string
+		Date      string
+		Client    string
+		Project   string
+		StartTime string `mapstructure:"start_time"`
+		EndTime   string `mapstructure:"end_time"`
+		Breaks    string
+		Status    string
+		Tags      string
+		Note      string
+	}
+}
+
 type Remote interface {
 	Type() string // TODO: change to specific preset type RemoteType
 	String() string
@@ -39,6 +78,7 @@ type SpreadsheetRemote struct {
 	ServiceAccountJsonPath string   `mapstructure:"service_account_json_path"`
 	Columns                struct { // TODO: this is duplicated in sheet.go, find a better way
 		ID        string `validate:"required"`
+		UUID      string
 		Client    string `validate:"required"`
 		Date      string `validate:"required"`
 		StartTime string `mapstructure:"start_time" validate:"required"`
@@ -56,6 +96,38 @@ func (s *SpreadsheetRemote) String() string {
 	return fmt.Sprintf("[%s] (%s)", s.Type(), s.ID)
 }
 
+// FileRemote syncs sessions with a CSV or JSON file, e.g. in a folder shared
+// through Dropbox or Syncthing. The format defaults to the file's extension.
+type FileRemote struct {
+	Path   string `mapstructure:"path" validate:"required"`
+	Format string `mapstructure:"format" validate:"oneof=csv json"`
+}
+
+func (f *FileRemote) Type() string {
+	return "file"
+}
+
+func (f *FileRemote) String() string {
+	return fmt.Sprintf("[%s] (%s)", f.Type(), f.Path)
+}
+
+// GitRemote syncs sessions through a local clone of a git repository, with a
+// file of sessions per month. The clone is created from the URL when missing.
+type GitRemote struct {
+	Path   string `mapstructure:"path" validate:"required"`
+	URL    string `mapstructure:"url"`
+	Branch string `mapstructure:"branch"`
+	Format string `mapstructure:"format" validate:"oneof=yaml json"`
+}
+
+func (g *GitRemote) Type() string {
+	return "git"
+}
+
+func (g *GitRemote) String() string {
+	return fmt.Sprintf("[%s] (%s)", g.Type(), g.Path)
+}
+
 func InitConfig(configPaths ...string) (*Config, error) {
 	var configPath string
 	if len(configPaths) > 0 {
@@ -91,6 +163,9 @@ func setupDefaultConfig(optionalConfigPath string) error {
 
 	viper.SetDefault("settings.default_currency", "USD")
 	viper.SetDefault("settings.editor", "vi")
+	viper.SetDefault("settings.concurrent_sessions", false)
+	viper.SetDefault("settings.overlaps", "reject")
+	viper.SetDefault("settings.week_start", "sunday")
 
 	if viper.IsSet("sync.engine") {
 		viper.SetDefault("sync.sync_actions", []string{"end"})
@@ -126,6 +201,7 @@ func loadConfig() (*Config, error) {
 	var intermediateConfig struct {
 		Settings Settings
 		Database Database
+		Import   Import
 		Remotes  map[string]any
 	}
 	if err := viper.Unmarshal(&intermediateConfig); err != nil {
@@ -135,6 +211,7 @@ func loadConfig() (*Config, error) {
 	conf := &Config{
 		Settings: intermediateConfig.Settings,
 		Database: intermediateConfig.Database,
+		Import:   intermediateConfig.Import,
 		Remotes:  make(map[string]Remote),
 	}
 
@@ -211,6 +288,36 @@ func unmarshalRemotes(remoteMap map[string]any, conf *Config) error {
 				remote.ServiceAccountJsonPath = filepath.Join(determineConfigPath(""), "service-account.json")
 			}
 
+			conf.Remotes[key] = &remote
+		case "file":
+			var remote FileRemote
+			if err := viper.UnmarshalKey(fmt.Sprintf("remotes.%s", key), &remote); err != nil {
+				return err
+			}
+
+			// set default values
+			if remote.Format == "" {
+				remote.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(remote.Path)), ".")
+			}
+
+			if err := validator.New().Struct(remote); err != nil {
+				return fmt.Errorf("remote '%s': %w", key, err)
+			}
+			conf.Remotes[key] = &remote
+		case "git":
+			var remote GitRemote
+			if err := viper.UnmarshalKey(fmt.Sprintf("remotes.%s", key), &remote); err != nil {
+				return err
+			}
+
+			// set default values
+			if remote.Format == "" {
+				remote.Format = "yaml"
+			}
+
+			if err := validator.New().Struct(remote); err != nil {
+				return fmt.Errorf("remote '%s': %w", key, err)
+			}
 			conf.Remotes[key] = &remote
 		default:
 			return fmt.Errorf("unknown remote type '%s'", remoteType)
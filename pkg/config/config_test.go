@@ -20,6 +20,9 @@ func TestConfig_InitConfig_Defaults(t *testing.T) {
 
 	assert.Equal(t, "sqlite3", config.Database.Engine)
 	assert.Equal(t, filepath.Join(tempDir, "punch.db"), config.Database.Path)
+	assert.False(t, config.Settings.ConcurrentSessions)
+	assert.Equal(t, "reject", config.Settings.Overlaps)
+	assert.Equal(t, "sunday", config.Settings.WeekStart)
 }
 
 func TestConfig_InitConfig_FromFile(t *testing.T) {
@@ -77,6 +80,77 @@ func TestConfig_InitConfig_AllowedRemoteUsed(t *testing.T) {
 	assert.Equal(t, "spreadsheet", config.Remotes[remoteName].Type())
 }
 
+func TestConfig_InitConfig_FileRemoteFormatFromExtension(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.toml")
+	viper.AddConfigPath(tempDir)
+	viper.SetConfigType("toml")
+
+	fileRemote := `
+        [remotes.dropbox]
+        type = "file"
+        path = "/tmp/sessions.JSON"
+        `
+
+	err := os.WriteFile(configFile, []byte(fileRemote), 0644)
+	assert.NoError(t, err)
+
+	config, err := InitConfig(tempDir)
+	assert.NoError(t, err)
+
+	remote, ok := config.Remotes["dropbox"].(*FileRemote)
+	assert.True(t, ok)
+	assert.Equal(t, "file", remote.Type())
+	assert.Equal(t, "json", remote.Format)
+}
+
+func TestConfig_InitConfig_FileRemoteUnknownFormatReturnsError(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.toml")
+	viper.AddConfigPath(tempDir)
+	viper.SetConfigType("toml")
+
+	fileRemote := `
+        [remotes.dropbox]
+        type = "file"
+        path = "/tmp/sessions.txt"
+        `
+
+	err := os.WriteFile(configFile, []byte(fileRemote), 0644)
+	assert.NoError(t, err)
+
+	config, err := InitConfig(tempDir)
+
+	assert.Error(t, err)
+	assert.Nil(t, config)
+}
+
+func TestConfig_InitConfig_GitRemoteDefaultsToYAML(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.toml")
+	viper.AddConfigPath(tempDir)
+	viper.SetConfigType("toml")
+
+	gitRemote := `
+        [remotes.team]
+        type = "git"
+        path = "/tmp/timesheets"
+        url = "git@example.com:team/timesheets.git"
+        `
+
+	err := os.WriteFile(configFile, []byte(gitRemote), 0644)
+	assert.NoError(t, err)
+
+	config, err := InitConfig(tempDir)
+	assert.NoError(t, err)
+
+	remote, ok := config.Remotes["team"].(*GitRemote)
+	assert.True(t, ok)
+	assert.Equal(t, "git", remote.Type())
+	assert.Equal(t, "yaml", remote.Format)
+	assert.Equal(t, "git@example.com:team/timesheets.git", remote.URL)
+}
+
 func TestConfig_InitConfig_NotSupportedRemoteReturnsError(t *testing.T) {
 	tempDir := t.TempDir()
 	configFile := filepath.Join(tempDir, "config.toml")
@@ -24,11 +24,24 @@ func NewDatabase(engine string, path string) (*gorm.DB, error) {
 
 	// TODO: use default currency for RepoClient
 	err = db.AutoMigrate(&repositories.RepoClient{},
-		&repositories.RepoSession{})
+		&repositories.RepoHourlyRate{},
+		&repositories.RepoProject{},
+		&repositories.RepoSession{},
+		&repositories.RepoBreak{},
+		&repositories.RepoTombstone{},
+		&repositories.RepoTag{},
+		&repositories.RepoInvoice{},
+		&repositories.RepoExchangeRate{},
+		&repositories.RepoSyncSnapshot{})
 
 	if err != nil {
 		return nil, err
 	}
 
+	err = repositories.BackfillSessionUUIDs(db)
+	if err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
@@ -0,0 +1,258 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+)
+
+const (
+	DEFAULT_CSV_DATE_FORMAT = "2006-01-02"
+	DEFAULT_CSV_TIME_FORMAT = "15:04:05"
+)
+
+// CSVColumns maps session fields to the CSV header names, an empty name means
+// the column is not part of the layout.
+type CSVColumns struct {
+	ID        string
+	Date      string
+	Client    string
+	Project   string
+	StartTime string
+	EndTime   string
+	Breaks    string
+	Status    string
+	Tags      string
+	Note      string
+}
+
+// DefaultCSVColumns is the layout of `punch get session -o csv -v`.
+func DefaultCSVColumns() CSVColumns {
+	return CSVColumns{
+		ID:        "id",
+		Date:      "date",
+		Client:    "client",
+		Project:   "project",
+		StartTime: "start_time",
+		EndTime:   "end_time",
+		Breaks:    "breaks",
+		Status:    "status",
+		Tags:      "tags",
+		Note:      "note",
+	}
+}
+
+type CSVImporter struct {
+	Columns    CSVColumns
+	DateFormat string
+	TimeFormat string
+}
+
+func NewCSVImporter(columns CSVColumns, dateFormat string, timeFormat string) *CSVImporter {
+	if dateFormat == "" {
+		dateFormat = DEFAULT_CSV_DATE_FORMAT
+	}
+	if timeFormat == "" {
+		timeFormat = DEFAULT_CSV_TIME_FORMAT
+	}
+	return &CSVImporter{
+		Columns:    columns,
+		DateFormat: dateFormat,
+		TimeFormat: timeFormat,
+	}
+}
+
+func (i *CSVImporter) Type() string {
+	return "csv"
+}
+
+// Parse reads all the rows of the CSV, rows that fail validation are reported
+// together in the returned error.
+func (i *CSVImporter) Parse(reader io.Reader) ([]Record, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := i.columnIndexes(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	var rowErrors []error
+	row := 1
+	for {
+		fields, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		row++
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		session, err := i.parseRow(fields, indexes)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		records = append(records, Record{Row: row, Session: *session})
+	}
+
+	return records, errors.Join(rowErrors...)
+}
+
+func (i *CSVImporter) columnIndexes(header []string) (map[string]int, error) {
+	positions := make(map[string]int)
+	for position, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = position
+	}
+
+	indexes := make(map[string]int)
+	columns := []struct {
+		field    string
+		name     string
+		required bool
+	}{
+		{"id", i.Columns.ID, false},
+		{"date", i.Columns.Date, true},
+		{"client", i.Columns.Client, true},
+		{"project", i.Columns.Project, false},
+		{"start_time", i.Columns.StartTime, true},
+		{"end_time", i.Columns.EndTime, true},
+		{"breaks", i.Columns.Breaks, false},
+		{"status", i.Columns.Status, false},
+		{"tags", i.Columns.Tags, false},
+		{"note", i.Columns.Note, false},
+	}
+	for _, column := range columns {
+		position, ok := positions[strings.ToLower(column.name)]
+		if column.name != "" && ok {
+			indexes[column.field] = position
+		} else if column.required {
+			return nil, fmt.Errorf("%w `%s` for %s", ErrMissingColumn, column.name, column.field)
+		}
+	}
+	return indexes, nil
+}
+
+func (i *CSVImporter) parseRow(fields []string, indexes map[string]int) (*models.Session, error) {
+	field := func(name string) string {
+		index, ok := indexes[name]
+		if !ok || index >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[index])
+	}
+
+	var id uint32
+	if field("id") != "" {
+		parsed, err := strconv.ParseUint(field("id"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id `%s`", field("id"))
+		}
+		id = uint32(parsed)
+	}
+
+	clientName := field("client")
+	if clientName == "" {
+		return nil, errors.New("missing client")
+	}
+	client := models.Client{Name: clientName}
+
+	layout := i.DateFormat + " " + i.TimeFormat
+	start, err := time.ParseInLocation(layout, field("date")+" "+field("start_time"), time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start `%s %s`", field("date"), field("start_time"))
+	}
+
+	if field("end_time") == "N/A" || field("end_time") == "" {
+		return nil, ErrUnfinishedSession
+	}
+	end, err := time.ParseInLocation(layout, field("date")+" "+field("end_time"), time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end time `%s`", field("end_time"))
+	}
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	breaks, err := i.parseBreaks(field("breaks"), start)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := models.ParseSessionStatus(field("status"))
+	if field("status") != "" && err != nil {
+		return nil, err
+	}
+
+	var project *models.Project
+	if field("project") != "" {
+		project = &models.Project{Name: field("project"), Client: client}
+	}
+
+	return &models.Session{
+		ID:      id,
+		Client:  client,
+		Project: project,
+		Start:   start,
+		End:     end,
+		Breaks:  breaks,
+		Note:    field("note"),
+		Tags:    models.NormalizeTags(strings.Split(field("tags"), ",")),
+		Status:  status,
+	}, nil
+}
+
+// parseBreaks reads the breaks of a session as start/end times separated by
+// semicolons. Times earlier than the session start (or than the break start)
+// are on the following day.
+func (i *CSVImporter) parseBreaks(value string, sessionStart time.Time) ([]models.Break, error) {
+	if value == "" {
+		return nil, nil
+	}
+	layout := i.DateFormat + " " + i.TimeFormat
+	date := sessionStart.Format(i.DateFormat)
+	parse := func(clock string, after time.Time) (time.Time, error) {
+		t, err := time.ParseInLocation(layout, date+" "+strings.TrimSpace(clock), time.Local)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for t.Before(after) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+
+	var breaks []models.Break
+	for _, interval := range strings.Split(value, ";") {
+		startClock, endClock, _ := strings.Cut(interval, "/")
+		if strings.TrimSpace(endClock) == "" {
+			return nil, fmt.Errorf("unfinished break `%s`", interval)
+		}
+		start, err := parse(startClock, sessionStart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid break `%s`", interval)
+		}
+		end, err := parse(endClock, start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid break `%s`", interval)
+		}
+		breaks = append(breaks, models.Break{Start: start, End: end})
+	}
+	return breaks, nil
+}
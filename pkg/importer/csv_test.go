@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVImporter_Parse_FullCSV(t *testing.T) {
+	input := `id,date,client,start_time,end_time,duration,amount,currency,status,note
+4,2026-09-01,Acme,09:00:00,12:00:00,03:00:00,300.00,USD,paid,"Design, review"
+5,2026-09-01,Acme,23:00:00,01:00:00,02:00:00,200.00,USD,unbilled,
+`
+	csvImporter := NewCSVImporter(DefaultCSVColumns(), "", "")
+	records, err := csvImporter.Parse(strings.NewReader(input))
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, 2, records[0].Row)
+	assert.Equal(t, uint32(4), records[0].Session.ID)
+	assert.Equal(t, "Acme", records[0].Session.Client.Name)
+	assert.Equal(t, time.Date(2026, time.September, 1, 9, 0, 0, 0, time.Local), records[0].Session.Start)
+	assert.Equal(t, 3*time.Hour, records[0].Session.WorkDuration())
+	assert.Equal(t, "Design, review", records[0].Session.Note)
+	assert.Equal(t, models.SESSION_STATUS_PAID, records[0].Session.Status)
+	assert.Equal(t, time.Date(2026, time.September, 2, 1, 0, 0, 0, time.Local), records[1].Session.End)
+}
+
+func TestCSVImporter_Parse_RoundTripsFullCSV(t *testing.T) {
+	start := time.Date(2026, time.September, 1, 22, 0, 0, 0, time.Local)
+	client := models.Client{Name: "Acme", PPH: 100, Currency: "USD"}
+	session := models.Session{
+		ID:      1,
+		Client:  client,
+		Project: &models.Project{Name: "Web", Client: client},
+		Start:   start,
+		End:     start.Add(4 * time.Hour),
+		Breaks: []models.Break{
+			{Start: start.Add(30 * time.Minute), End: start.Add(45 * time.Minute)},
+			{Start: start.Add(110 * time.Minute), End: start.Add(130 * time.Minute)},
+		},
+		Tags: []string{"dev", "ops"},
+		Note: `Said "hi", left`,
+	}
+	buf, err := models.SerializeSessionsToFullCSV([]models.Session{session})
+	assert.NoError(t, err)
+
+	records, err := NewCSVImporter(DefaultCSVColumns(), "", "").Parse(buf)
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.True(t, records[0].Session.Equals(session))
+}
+
+func TestCSVImporter_Parse_CustomLayout(t *testing.T) {
+	input := `Customer,Day,From,To,Description
+Acme,01/09/2026,09:00,10:30,Support
+`
+	columns := CSVColumns{
+		Client:    "Customer",
+		Date:      "Day",
+		StartTime: "From",
+		EndTime:   "To",
+		Note:      "Description",
+	}
+	records, err := NewCSVImporter(columns, "02/01/2006", "15:04").Parse(strings.NewReader(input))
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, time.Date(2026, time.September, 1, 9, 0, 0, 0, time.Local), records[0].Session.Start)
+	assert.Equal(t, "Support", records[0].Session.Note)
+}
+
+func TestCSVImporter_Parse_MissingRequiredColumn(t *testing.T) {
+	input := "id,date,start_time,end_time\n"
+	_, err := NewCSVImporter(DefaultCSVColumns(), "", "").Parse(strings.NewReader(input))
+
+	assert.ErrorIs(t, err, ErrMissingColumn)
+}
+
+func TestCSVImporter_Parse_ReportsEveryInvalidRow(t *testing.T) {
+	input := `date,client,start_time,end_time,breaks
+2026-09-01,Acme,09:00:00,10:00:00,09:15:00/09:30:00
+2026-09-01,,09:00:00,10:00:00,
+2026-13-01,Acme,09:00:00,10:00:00,
+2026-09-01,Acme,09:00:00,N/A,
+2026-09-01,Acme,09:00:00,10:00:00,09:15:00/
+`
+	records, err := NewCSVImporter(DefaultCSVColumns(), "", "").Parse(strings.NewReader(input))
+
+	assert.Len(t, records, 1)
+	assert.Equal(t, 45*time.Minute, records[0].Session.WorkDuration())
+	assert.ErrorContains(t, err, "row 3: missing client")
+	assert.ErrorContains(t, err, "row 4: invalid start")
+	assert.ErrorIs(t, err, ErrUnfinishedSession)
+	assert.ErrorContains(t, err, "row 6: unfinished break")
+}
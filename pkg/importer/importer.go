@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dormunis/punch/pkg/models"
+)
+
+var (
+	ErrImporterNotSupported = errors.New("importer not implemented")
+	ErrMissingColumn        = errors.New("missing column")
+	ErrUnfinishedSession    = errors.New("unfinished sessions cannot be imported")
+)
+
+// Importer parses sessions exported by punch or by other time trackers.
+// Parsed sessions only reference their client and project by name.
+type Importer interface {
+	Type() string
+	Parse(reader io.Reader) ([]Record, error)
+}
+
+// Record is a session parsed from a specific row (or line) of the input.
+type Record struct {
+	Row     int
+	Session models.Session
+}
+
+// RowError is a validation error of a specific row (or line) of the input.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
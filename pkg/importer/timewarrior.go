@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+)
+
+const TIMEWARRIOR_TIME_FORMAT = "20060102T150405Z"
+
+// TimewarriorImporter parses the JSON output of `timew export`. The first tag
+// of an interval is its client, the rest of the tags are kept as tags and the
+// annotation becomes the session's note.
+type TimewarriorImporter struct{}
+
+type timewarriorInterval struct {
+	ID         int      `json:"id"`
+	Start      string   `json:"start"`
+	End        string   `json:"end"`
+	Tags       []string `json:"tags"`
+	Annotation string   `json:"annotation"`
+}
+
+func NewTimewarriorImporter() *TimewarriorImporter {
+	return &TimewarriorImporter{}
+}
+
+func (i *TimewarriorImporter) Type() string {
+	return "timewarrior"
+}
+
+func (i *TimewarriorImporter) Parse(reader io.Reader) ([]Record, error) {
+	var intervals []timewarriorInterval
+	err := json.NewDecoder(reader).Decode(&intervals)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	var rowErrors []error
+	for index, interval := range intervals {
+		row := index + 1
+		session, err := parseTimewarriorInterval(interval)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		records = append(records, Record{Row: row, Session: *session})
+	}
+	return records, errors.Join(rowErrors...)
+}
+
+func parseTimewarriorInterval(interval timewarriorInterval) (*models.Session, error) {
+	if len(interval.Tags) == 0 {
+		return nil, errors.New("interval has no tags to map to a client")
+	}
+	start, err := time.Parse(TIMEWARRIOR_TIME_FORMAT, interval.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start `%s`", interval.Start)
+	}
+	if interval.End == "" {
+		return nil, ErrUnfinishedSession
+	}
+	end, err := time.Parse(TIMEWARRIOR_TIME_FORMAT, interval.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end `%s`", interval.End)
+	}
+
+	return &models.Session{
+		Client: models.Client{Name: interval.Tags[0]},
+		Start:  start.In(time.Local),
+		End:    end.In(time.Local),
+		Note:   interval.Annotation,
+		Tags:   models.NormalizeTags(interval.Tags[1:]),
+	}, nil
+}
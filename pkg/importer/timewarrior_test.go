@@ -0,0 +1,26 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimewarriorImporter_Parse(t *testing.T) {
+	input := `[
+{"id":2,"start":"20240115T090000Z","end":"20240115T100000Z","tags":["Acme","dev"],"annotation":"Fix bug"},
+{"id":1,"start":"20240115T110000Z","tags":["Acme"]},
+{"id":3,"start":"20240115T120000Z","end":"20240115T130000Z"}
+]`
+	records, err := NewTimewarriorImporter().Parse(strings.NewReader(input))
+
+	assert.Len(t, records, 1)
+	assert.Equal(t, "Acme", records[0].Session.Client.Name)
+	assert.Equal(t, []string{"dev"}, records[0].Session.Tags)
+	assert.Equal(t, "Fix bug", records[0].Session.Note)
+	assert.True(t, records[0].Session.Start.Equal(time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC)))
+	assert.ErrorIs(t, err, ErrUnfinishedSession)
+	assert.ErrorContains(t, err, "row 3: interval has no tags")
+}
@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+)
+
+// TrackerCSVImporter parses the detailed CSV reports of Toggl Track and
+// Clockify. Entries are billed to their client, or to their project when
+// they have no client, and the description becomes the session's note.
+type TrackerCSVImporter struct {
+	name        string
+	dateLayouts []string
+	timeLayouts []string
+}
+
+func NewTogglImporter() *TrackerCSVImporter {
+	return &TrackerCSVImporter{
+		name:        "toggl",
+		dateLayouts: []string{"2006-01-02", "01/02/2006", "02/01/2006"},
+		timeLayouts: []string{"15:04:05", "15:04"},
+	}
+}
+
+func NewClockifyImporter() *TrackerCSVImporter {
+	return &TrackerCSVImporter{
+		name:        "clockify",
+		dateLayouts: []string{"01/02/2006", "2006-01-02", "02/01/2006", "02.01.2006"},
+		timeLayouts: []string{"03:04:05 PM", "03:04 PM", "15:04:05", "15:04"},
+	}
+}
+
+func (i *TrackerCSVImporter) Type() string {
+	return i.name
+}
+
+func (i *TrackerCSVImporter) Parse(reader io.Reader) ([]Record, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[string]int)
+	for position, name := range header {
+		// exports may start with a byte order mark
+		name = strings.TrimPrefix(name, "\ufeff")
+		positions[strings.ToLower(strings.TrimSpace(name))] = position
+	}
+	for _, required := range []string{"start date", "start time", "end date", "end time"} {
+		if _, ok := positions[required]; !ok {
+			return nil, fmt.Errorf("%w `%s`", ErrMissingColumn, required)
+		}
+	}
+
+	var records []Record
+	var rowErrors []error
+	row := 1
+	for {
+		fields, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		row++
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		field := func(name string) string {
+			position, ok := positions[name]
+			if !ok || position >= len(fields) {
+				return ""
+			}
+			return strings.TrimSpace(fields[position])
+		}
+
+		session, err := i.parseEntry(field)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		records = append(records, Record{Row: row, Session: *session})
+	}
+	return records, errors.Join(rowErrors...)
+}
+
+func (i *TrackerCSVImporter) parseEntry(field func(string) string) (*models.Session, error) {
+	clientName, projectName := field("client"), field("project")
+	if clientName == "" {
+		clientName, projectName = projectName, ""
+	}
+	if clientName == "" {
+		return nil, errors.New("entry has neither a client nor a project")
+	}
+	client := models.Client{Name: clientName}
+
+	start, err := i.parseDateTime(field("start date"), field("start time"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %v", err)
+	}
+	if field("end date") == "" || field("end time") == "" {
+		return nil, ErrUnfinishedSession
+	}
+	end, err := i.parseDateTime(field("end date"), field("end time"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid end: %v", err)
+	}
+	if !end.After(start) {
+		return nil, errors.New("entry ends before it starts")
+	}
+
+	var project *models.Project
+	if projectName != "" {
+		project = &models.Project{Name: projectName, Client: client}
+	}
+	return &models.Session{
+		Client:  client,
+		Project: project,
+		Start:   start,
+		End:     end,
+		Note:    field("description"),
+		Tags:    models.NormalizeTags(strings.Split(field("tags"), ",")),
+	}, nil
+}
+
+func (i *TrackerCSVImporter) parseDateTime(date string, clock string) (time.Time, error) {
+	for _, dateLayout := range i.dateLayouts {
+		for _, timeLayout := range i.timeLayouts {
+			parsed, err := time.ParseInLocation(dateLayout+" "+timeLayout, date+" "+clock, time.Local)
+			if err == nil {
+				return parsed, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date `%s %s`", date, clock)
+}
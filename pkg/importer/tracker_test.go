@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTogglImporter_Parse(t *testing.T) {
+	input := "\ufeffUser,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags\n" +
+		"Jane,jane@example.com,Acme,Website,,Landing page,Yes,2024-01-15,09:00:00,2024-01-15,10:30:00,01:30:00,\"design, dev\"\n" +
+		"Jane,jane@example.com,,Internal,,Planning,No,2024-01-15,11:00:00,2024-01-15,11:30:00,00:30:00,\n"
+
+	records, err := NewTogglImporter().Parse(strings.NewReader(input))
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "Acme", records[0].Session.Client.Name)
+	assert.Equal(t, "Website", records[0].Session.ProjectName())
+	assert.Equal(t, "Landing page", records[0].Session.Note)
+	assert.Equal(t, []string{"design", "dev"}, records[0].Session.Tags)
+	assert.Equal(t, 90*time.Minute, records[0].Session.WorkDuration())
+	assert.Equal(t, "Internal", records[1].Session.Client.Name)
+	assert.Nil(t, records[1].Session.Project)
+}
+
+func TestClockifyImporter_Parse(t *testing.T) {
+	input := `Project,Client,Description,Task,User,Group,Email,Tags,Billable,Start Date,Start Time,End Date,End Time,Duration (h),Duration (decimal)
+Website,Acme,Review,,Jane,,jane@example.com,,Yes,01/15/2024,11:00:00 PM,01/16/2024,01:00:00 AM,02:00:00,2.00
+`
+	records, err := NewClockifyImporter().Parse(strings.NewReader(input))
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, time.Date(2024, time.January, 15, 23, 0, 0, 0, time.Local), records[0].Session.Start)
+	assert.Equal(t, time.Date(2024, time.January, 16, 1, 0, 0, 0, time.Local), records[0].Session.End)
+	assert.Empty(t, records[0].Session.Tags)
+}
+
+func TestTrackerCSVImporter_Parse_InvalidEntries(t *testing.T) {
+	input := `Client,Project,Description,Start date,Start time,End date,End time
+,,Nothing,2024-01-15,09:00:00,2024-01-15,10:00:00
+Acme,,Backwards,2024-01-15,10:00:00,2024-01-15,09:00:00
+`
+	records, err := NewTogglImporter().Parse(strings.NewReader(input))
+
+	assert.Empty(t, records)
+	assert.ErrorContains(t, err, "row 2: entry has neither a client nor a project")
+	assert.ErrorContains(t, err, "row 3: entry ends before it starts")
+}
@@ -0,0 +1,193 @@
+package invoice
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+)
+
+type GroupBy string
+
+const (
+	GROUP_BY_DAY  GroupBy = "day"
+	GROUP_BY_NOTE GroupBy = "note"
+)
+
+type Format string
+
+const (
+	FORMAT_TEXT     Format = "text"
+	FORMAT_MARKDOWN Format = "markdown"
+	FORMAT_HTML     Format = "html"
+)
+
+const NO_NOTE_DESCRIPTION = "General work"
+
+var (
+	ErrUnsupportedGroupBy = errors.New("unsupported group by, must be one of: day, note")
+	ErrUnsupportedFormat  = errors.New("unsupported format, must be one of: text, markdown, html")
+	ErrNoSessions         = errors.New("no sessions to invoice")
+)
+
+//go:embed templates/*
+var templates embed.FS
+
+// Line is a single billed item of an invoice, sessions with the same
+// description and hourly rate are aggregated into the same line.
+type Line struct {
+	Description string
+	Duration    time.Duration
+	Rate        uint16
+	Amount      float64
+}
+
+// Document is an invoice along with its billed lines, ready to be rendered.
+// The reporting total is only set once the document is converted to another
+// currency.
+type Document struct {
+	models.Invoice
+	Lines             []Line
+	Duration          time.Duration
+	Total             float64
+	Currency          string
+	ReportingTotal    float64
+	ReportingCurrency string
+}
+
+// NewDocument groups the given sessions into invoice lines, sessions are
+// billed for their rounded duration according to the hourly rate in force
+// when they started.
+func NewDocument(invoice models.Invoice, sessions []models.Session, groupBy GroupBy) (*Document, error) {
+	if groupBy != GROUP_BY_DAY && groupBy != GROUP_BY_NOTE {
+		return nil, ErrUnsupportedGroupBy
+	}
+	if len(sessions) == 0 {
+		return nil, ErrNoSessions
+	}
+
+	sorted := make([]models.Session, len(sessions))
+	copy(sorted, sessions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	document := &Document{
+		Invoice:  invoice,
+		Currency: invoice.Client.Currency,
+	}
+	lineIndex := make(map[string]int)
+	for _, session := range sorted {
+		earnings, err := session.Earnings()
+		if err != nil {
+			return nil, fmt.Errorf("unable to invoice session %d: %v", session.ID, err)
+		}
+
+		description := lineDescription(session, groupBy)
+		key := fmt.Sprintf("%s\x00%d", description, session.Rate())
+		i, ok := lineIndex[key]
+		if !ok {
+			document.Lines = append(document.Lines, Line{
+				Description: description,
+				Rate:        session.Rate(),
+			})
+			i = len(document.Lines) - 1
+			lineIndex[key] = i
+		}
+
+		duration := session.BillableDuration()
+		document.Lines[i].Duration += duration
+		document.Lines[i].Amount += earnings
+		document.Duration += duration
+		document.Total += earnings
+	}
+	return document, nil
+}
+
+// Convert sets the total of the invoiced sessions in the reporting currency,
+// each session is converted with the exchange rate in force when it started.
+func (d *Document) Convert(sessions []models.Session, rates models.ExchangeRates) error {
+	if strings.EqualFold(rates.Currency, d.Currency) {
+		return nil
+	}
+	var total float64
+	for _, session := range sessions {
+		converted, err := rates.ConvertEarnings(session)
+		if err != nil {
+			return fmt.Errorf("unable to convert session %d: %w", session.ID, err)
+		}
+		total += converted
+	}
+	d.ReportingTotal = total
+	d.ReportingCurrency = rates.Currency
+	return nil
+}
+
+func lineDescription(session models.Session, groupBy GroupBy) string {
+	if groupBy == GROUP_BY_DAY {
+		return session.Start.Format("2006-01-02")
+	}
+	if session.Note == "" {
+		return NO_NOTE_DESCRIPTION
+	}
+	return session.Note
+}
+
+// Render renders the document in the given format using the embedded
+// templates.
+func (d *Document) Render(format Format) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FORMAT_TEXT:
+		err = renderText(&buf, "templates/invoice.txt.tmpl", d)
+	case FORMAT_MARKDOWN:
+		err = renderText(&buf, "templates/invoice.md.tmpl", d)
+	case FORMAT_HTML:
+		var tmpl *htmltemplate.Template
+		tmpl, err = htmltemplate.New("invoice.html.tmpl").
+			Funcs(htmltemplate.FuncMap(templateFuncs)).
+			ParseFS(templates, "templates/invoice.html.tmpl")
+		if err == nil {
+			err = tmpl.Execute(&buf, d)
+		}
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+func renderText(buf *bytes.Buffer, path string, d *Document) error {
+	tmpl, err := template.New(path[len("templates/"):]).
+		Funcs(templateFuncs).
+		ParseFS(templates, path)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(buf, d)
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("2006-01-02")
+	},
+	"hours": func(d time.Duration) string {
+		return fmt.Sprintf("%.2f", d.Hours())
+	},
+	"amount": func(amount float64) string {
+		return fmt.Sprintf("%.2f", amount)
+	},
+	"lastDay": func(t time.Time) string {
+		return t.Add(-time.Nanosecond).Format("2006-01-02")
+	},
+}
@@ -0,0 +1,165 @@
+package invoice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleInvoice() models.Invoice {
+	client := models.Client{Name: "Acme", PPH: 100, Currency: "USD"}
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	return models.Invoice{
+		Number:   7,
+		Client:   client,
+		IssuedAt: time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC),
+		From:     from,
+		To:       from.AddDate(0, 1, 0),
+	}
+}
+
+func sampleSession(client models.Client, start time.Time, hours int, note string) models.Session {
+	return models.Session{
+		Client: client,
+		Start:  start,
+		End:    start.Add(time.Duration(hours) * time.Hour),
+		Note:   note,
+	}
+}
+
+func sampleSessions(client models.Client) []models.Session {
+	day := time.Date(2026, time.September, 2, 9, 0, 0, 0, time.UTC)
+	return []models.Session{
+		sampleSession(client, day.AddDate(0, 0, 1), 1, "meeting"),
+		sampleSession(client, day, 2, "development"),
+		sampleSession(client, day.Add(4*time.Hour), 3, "meeting"),
+	}
+}
+
+func TestNewDocument_GroupByDay(t *testing.T) {
+	invoice := sampleInvoice()
+	document, err := NewDocument(invoice, sampleSessions(invoice.Client), GROUP_BY_DAY)
+
+	assert.NoError(t, err)
+	assert.Len(t, document.Lines, 2)
+	assert.Equal(t, "2026-09-02", document.Lines[0].Description)
+	assert.Equal(t, 5*time.Hour, document.Lines[0].Duration)
+	assert.InDelta(t, 500.0, document.Lines[0].Amount, 0.001)
+	assert.Equal(t, "2026-09-03", document.Lines[1].Description)
+	assert.InDelta(t, 600.0, document.Total, 0.001)
+	assert.Equal(t, 6*time.Hour, document.Duration)
+}
+
+func TestNewDocument_GroupByNote(t *testing.T) {
+	invoice := sampleInvoice()
+	document, err := NewDocument(invoice, sampleSessions(invoice.Client), GROUP_BY_NOTE)
+
+	assert.NoError(t, err)
+	assert.Len(t, document.Lines, 2)
+	assert.Equal(t, "development", document.Lines[0].Description)
+	assert.Equal(t, "meeting", document.Lines[1].Description)
+	assert.Equal(t, 4*time.Hour, document.Lines[1].Duration)
+}
+
+func TestNewDocument_SplitsLinesByRate(t *testing.T) {
+	invoice := sampleInvoice()
+	sessions := sampleSessions(invoice.Client)
+	pph := uint16(200)
+	sessions[2].Project = &models.Project{Name: "Web", Client: invoice.Client, PPH: &pph}
+
+	document, err := NewDocument(invoice, sessions, GROUP_BY_NOTE)
+
+	assert.NoError(t, err)
+	assert.Len(t, document.Lines, 3)
+	assert.Equal(t, uint16(200), document.Lines[1].Rate)
+	assert.InDelta(t, 900.0, document.Total, 0.001)
+}
+
+func TestNewDocument_BillsRoundedDuration(t *testing.T) {
+	invoice := sampleInvoice()
+	invoice.Client.Rounding = models.Rounding{Minimum: 120}
+	document, err := NewDocument(invoice, sampleSessions(invoice.Client), GROUP_BY_DAY)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, document.Lines[1].Duration)
+	assert.InDelta(t, 200.0, document.Lines[1].Amount, 0.001)
+	assert.Equal(t, 7*time.Hour, document.Duration)
+}
+
+func TestNewDocument_NoSessions(t *testing.T) {
+	_, err := NewDocument(sampleInvoice(), nil, GROUP_BY_DAY)
+	assert.ErrorIs(t, err, ErrNoSessions)
+}
+
+func TestNewDocument_UnsupportedGroupBy(t *testing.T) {
+	invoice := sampleInvoice()
+	_, err := NewDocument(invoice, sampleSessions(invoice.Client), GroupBy("week"))
+	assert.ErrorIs(t, err, ErrUnsupportedGroupBy)
+}
+
+func TestDocument_Convert(t *testing.T) {
+	invoice := sampleInvoice()
+	sessions := sampleSessions(invoice.Client)
+	document, err := NewDocument(invoice, sessions, GROUP_BY_DAY)
+	assert.NoError(t, err)
+	rates := models.ExchangeRates{Currency: "EUR", Rates: []models.ExchangeRate{
+		{Date: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), Currency: "USD", Rate: 0.9},
+		{Date: time.Date(2026, time.September, 3, 0, 0, 0, 0, time.UTC), Currency: "USD", Rate: 0.8},
+	}}
+
+	err = document.Convert(sessions, rates)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", document.ReportingCurrency)
+	assert.InDelta(t, 530.0, document.ReportingTotal, 0.001)
+}
+
+func TestDocument_Convert_SameCurrency(t *testing.T) {
+	invoice := sampleInvoice()
+	sessions := sampleSessions(invoice.Client)
+	document, err := NewDocument(invoice, sessions, GROUP_BY_DAY)
+	assert.NoError(t, err)
+
+	err = document.Convert(sessions, models.ExchangeRates{Currency: "usd"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, document.ReportingCurrency)
+}
+
+func TestDocument_Render(t *testing.T) {
+	invoice := sampleInvoice()
+	document, err := NewDocument(invoice, sampleSessions(invoice.Client), GROUP_BY_NOTE)
+	assert.NoError(t, err)
+
+	for _, format := range []Format{FORMAT_TEXT, FORMAT_MARKDOWN, FORMAT_HTML} {
+		buf, err := document.Render(format)
+		assert.NoError(t, err, "Rendering %s should not return an error", format)
+		assert.Contains(t, buf.String(), "7", "Rendered %s invoice should contain its number", format)
+		assert.Contains(t, buf.String(), "2026-09-30", "Rendered %s invoice should contain the period's last day", format)
+		assert.Contains(t, buf.String(), "600.00", "Rendered %s invoice should contain the total", format)
+	}
+}
+
+func TestDocument_RenderHTMLEscapesNotes(t *testing.T) {
+	invoice := sampleInvoice()
+	sessions := []models.Session{
+		sampleSession(invoice.Client, invoice.From.Add(time.Hour), 1, "<script>"),
+	}
+	document, err := NewDocument(invoice, sessions, GROUP_BY_NOTE)
+	assert.NoError(t, err)
+
+	buf, err := document.Render(FORMAT_HTML)
+	assert.NoError(t, err)
+	assert.NotContains(t, buf.String(), "<script>")
+}
+
+func TestDocument_RenderUnsupportedFormat(t *testing.T) {
+	invoice := sampleInvoice()
+	document, err := NewDocument(invoice, sampleSessions(invoice.Client), GROUP_BY_DAY)
+	assert.NoError(t, err)
+
+	_, err = document.Render(Format("pdf"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
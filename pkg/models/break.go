@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+type Break struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (b Break) Finished() bool {
+	return !b.End.Equal(NULL_TIME)
+}
+
+// Until returns the length of the break, treating an unfinished break as
+// lasting until the given time.
+func (b Break) Until(end time.Time) time.Duration {
+	if b.Finished() {
+		end = b.End
+	}
+	if end.Before(b.Start) {
+		return 0
+	}
+	return end.Sub(b.Start)
+}
@@ -2,18 +2,129 @@ package models
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Client struct {
-	Name     string `yaml:"name"`
-	PPH      uint16 `yaml:"pph"`
-	Currency string `yaml:"currency"`
+	Name     string       `yaml:"name"`
+	PPH      uint16       `yaml:"pph"`
+	Currency string       `yaml:"currency"`
+	Rounding Rounding     `yaml:"rounding"`
+	Rates    []HourlyRate `yaml:"-"`
+}
+
+type RoundingMode string
+
+const (
+	ROUNDING_MODE_NEAREST RoundingMode = "nearest"
+	ROUNDING_MODE_UP      RoundingMode = "up"
+	ROUNDING_MODE_DOWN    RoundingMode = "down"
+)
+
+var ErrInvalidRoundingMode = errors.New("invalid rounding mode, must be one of nearest, up or down")
+
+// Rounding is a client's billing policy, it turns the worked time of a
+// session into its billable time. Worked time is rounded to a multiple of
+// Increment minutes (to the nearest one unless Mode says otherwise), and
+// billed for at least Minimum minutes.
+type Rounding struct {
+	Increment uint16       `yaml:"increment"`
+	Mode      RoundingMode `yaml:"mode"`
+	Minimum   uint16       `yaml:"minimum"`
+}
+
+func (r Rounding) Validate() error {
+	switch r.Mode {
+	case "", ROUNDING_MODE_NEAREST, ROUNDING_MODE_UP, ROUNDING_MODE_DOWN:
+		return nil
+	default:
+		return ErrInvalidRoundingMode
+	}
+}
+
+// Apply returns the billable time for the worked duration.
+func (r Rounding) Apply(duration time.Duration) time.Duration {
+	if duration <= 0 {
+		return duration
+	}
+	if r.Increment > 0 {
+		increment := time.Duration(r.Increment) * time.Minute
+		switch r.Mode {
+		case ROUNDING_MODE_UP:
+			if remainder := duration % increment; remainder != 0 {
+				duration += increment - remainder
+			}
+		case ROUNDING_MODE_DOWN:
+			duration = duration.Truncate(increment)
+		default:
+			duration = duration.Round(increment)
+		}
+	}
+	if minimum := time.Duration(r.Minimum) * time.Minute; duration < minimum {
+		duration = minimum
+	}
+	return duration
+}
+
+func (r Rounding) String() string {
+	var rules []string
+	if r.Increment > 0 {
+		mode := r.Mode
+		if mode == "" {
+			mode = ROUNDING_MODE_NEAREST
+		}
+		rules = append(rules, fmt.Sprintf("%s %dm", mode, r.Increment))
+	}
+	if r.Minimum > 0 {
+		rules = append(rules, fmt.Sprintf("min %dm", r.Minimum))
+	}
+	return strings.Join(rules, ", ")
+}
+
+// HourlyRate is an entry in a client's rate history, it is in force from
+// EffectiveFrom until the next entry. An entry with a zero EffectiveFrom is
+// the client's initial rate.
+type HourlyRate struct {
+	EffectiveFrom time.Time
+	PPH           uint16
+}
+
+// RateAt returns the hourly rate in force at the given time. Times before the
+// first recorded rate use the earliest known rate, and clients without any
+// history use their current rate.
+func (c Client) RateAt(t time.Time) uint16 {
+	if len(c.Rates) == 0 {
+		return c.PPH
+	}
+
+	earliest := c.Rates[0]
+	var current *HourlyRate
+	for i, rate := range c.Rates {
+		if rate.EffectiveFrom.Before(earliest.EffectiveFrom) {
+			earliest = rate
+		}
+		if rate.EffectiveFrom.After(t) {
+			continue
+		}
+		if current == nil || rate.EffectiveFrom.After(current.EffectiveFrom) {
+			current = &c.Rates[i]
+		}
+	}
+	if current == nil {
+		return earliest.PPH
+	}
+	return current.PPH
 }
 
 func (c Client) String() string {
+	if rounding := c.Rounding.String(); rounding != "" {
+		return fmt.Sprintf("%s\t%d %s\t(%s)", c.Name, c.PPH, c.Currency, rounding)
+	}
 	return fmt.Sprintf("%s\t%d %s", c.Name, c.PPH, c.Currency)
 }
 
@@ -36,5 +147,5 @@ func DeserializeClientFromYAML(buf *bytes.Buffer, client *Client) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return client.Rounding.Validate()
 }
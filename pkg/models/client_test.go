@@ -3,6 +3,7 @@ package models
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -40,3 +41,74 @@ func TestDeserializeClientFromYAML_InvalidYAML(t *testing.T) {
 
 	assert.Error(t, err, "Deserialize should return an error for invalid YAML")
 }
+
+func rateHistoryClient() Client {
+	return Client{
+		Name:     "Test Client",
+		PPH:      150,
+		Currency: "USD",
+		Rates: []HourlyRate{
+			{PPH: 100},
+			{EffectiveFrom: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), PPH: 120},
+			{EffectiveFrom: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), PPH: 150},
+		},
+	}
+}
+
+func TestClient_RateAt_NoHistory(t *testing.T) {
+	client := Client{Name: "Test Client", PPH: 100, Currency: "USD"}
+	assert.Equal(t, uint16(100), client.RateAt(time.Now()))
+}
+
+func TestClient_RateAt_BeforeFirstChange(t *testing.T) {
+	client := rateHistoryClient()
+	assert.Equal(t, uint16(100), client.RateAt(time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestClient_RateAt_OnEffectiveDate(t *testing.T) {
+	client := rateHistoryClient()
+	assert.Equal(t, uint16(120), client.RateAt(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestClient_RateAt_LatestRate(t *testing.T) {
+	client := rateHistoryClient()
+	assert.Equal(t, uint16(150), client.RateAt(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestRounding_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		rounding Rounding
+		worked   time.Duration
+		billable time.Duration
+	}{
+		{"no rules", Rounding{}, 7 * time.Minute, 7 * time.Minute},
+		{"up to 15 minutes", Rounding{Increment: 15, Mode: ROUNDING_MODE_UP}, 16 * time.Minute, 30 * time.Minute},
+		{"up keeps exact increments", Rounding{Increment: 15, Mode: ROUNDING_MODE_UP}, 30 * time.Minute, 30 * time.Minute},
+		{"nearest 6 minutes down", Rounding{Increment: 6}, 62 * time.Minute, time.Hour},
+		{"nearest 6 minutes up", Rounding{Increment: 6, Mode: ROUNDING_MODE_NEAREST}, 64 * time.Minute, 66 * time.Minute},
+		{"down to 15 minutes", Rounding{Increment: 15, Mode: ROUNDING_MODE_DOWN}, 29 * time.Minute, 15 * time.Minute},
+		{"minimum of an hour", Rounding{Minimum: 60}, 20 * time.Minute, time.Hour},
+		{"minimum after rounding", Rounding{Increment: 15, Mode: ROUNDING_MODE_UP, Minimum: 60}, 61 * time.Minute, 75 * time.Minute},
+		{"nothing worked", Rounding{Minimum: 60}, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.billable, test.rounding.Apply(test.worked))
+		})
+	}
+}
+
+func TestRounding_Validate(t *testing.T) {
+	assert.NoError(t, Rounding{}.Validate())
+	assert.NoError(t, Rounding{Increment: 15, Mode: ROUNDING_MODE_UP}.Validate())
+	assert.ErrorIs(t, Rounding{Mode: "sideways"}.Validate(), ErrInvalidRoundingMode)
+}
+
+func TestClient_DeserializeClientFromYAML_InvalidRounding(t *testing.T) {
+	var client Client
+	buf := bytes.NewBufferString("name: Test Client\npph: 100\nrounding:\n  increment: 15\n  mode: sideways\n")
+	err := DeserializeClientFromYAML(buf, &client)
+	assert.ErrorIs(t, err, ErrInvalidRoundingMode)
+}
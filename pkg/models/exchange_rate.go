@@ -0,0 +1,156 @@
+package models
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoExchangeRate      = errors.New("no exchange rate")
+	ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+)
+
+// ExchangeRate is the value of one unit of Currency in the reporting
+// currency, it is in force from Date until the next rate of that currency.
+type ExchangeRate struct {
+	Date     time.Time
+	Currency string
+	Rate     float64
+}
+
+func (r ExchangeRate) Validate() error {
+	if r.Date.IsZero() {
+		return fmt.Errorf("%w, date is missing", ErrInvalidExchangeRate)
+	}
+	if len(r.Currency) != 3 {
+		return fmt.Errorf("%w, currency `%s` must be a 3 letter code", ErrInvalidExchangeRate, r.Currency)
+	}
+	if r.Rate <= 0 {
+		return fmt.Errorf("%w, rate of %s must be positive", ErrInvalidExchangeRate, r.Currency)
+	}
+	return nil
+}
+
+// ExchangeRates converts amounts into a single reporting currency.
+type ExchangeRates struct {
+	Currency string
+	Rates    []ExchangeRate
+}
+
+// RateAt returns the rate of the currency in force at the given time, which
+// is the latest rate that is not dated after it.
+func (e ExchangeRates) RateAt(currency string, t time.Time) (float64, error) {
+	if strings.EqualFold(currency, e.Currency) {
+		return 1, nil
+	}
+	var current *ExchangeRate
+	for i, rate := range e.Rates {
+		if !strings.EqualFold(rate.Currency, currency) || rate.Date.After(t) {
+			continue
+		}
+		if current == nil || rate.Date.After(current.Date) {
+			current = &e.Rates[i]
+		}
+	}
+	if current == nil {
+		return 0, fmt.Errorf("%w from %s to %s on %s",
+			ErrNoExchangeRate, currency, e.Currency, t.Format("2006-01-02"))
+	}
+	return current.Rate, nil
+}
+
+// Convert returns the amount in the reporting currency, using the rate in
+// force at the given time.
+func (e ExchangeRates) Convert(amount float64, currency string, t time.Time) (float64, error) {
+	rate, err := e.RateAt(currency, t)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// ConvertEarnings returns the session's earnings in the reporting currency,
+// using the rate in force when the session started.
+func (e ExchangeRates) ConvertEarnings(session Session) (float64, error) {
+	earnings, err := session.Earnings()
+	if err != nil {
+		return 0, err
+	}
+	return e.Convert(earnings, session.Client.Currency, session.Start)
+}
+
+// SortExchangeRates orders rates by currency and then by date.
+func SortExchangeRates(rates []ExchangeRate) {
+	sort.SliceStable(rates, func(i, j int) bool {
+		if rates[i].Currency != rates[j].Currency {
+			return rates[i].Currency < rates[j].Currency
+		}
+		return rates[i].Date.Before(rates[j].Date)
+	})
+}
+
+// SerializeExchangeRatesToCSV writes the rates in the same date, currency and
+// rate layout that DeserializeExchangeRatesFromCSV reads.
+func SerializeExchangeRatesToCSV(w io.Writer, rates []ExchangeRate) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"date", "currency", "rate"})
+	if err != nil {
+		return err
+	}
+	for _, rate := range rates {
+		err = writer.Write([]string{
+			rate.Date.Format("2006-01-02"),
+			rate.Currency,
+			strconv.FormatFloat(rate.Rate, 'f', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// DeserializeExchangeRatesFromCSV reads rows of date (YYYY-MM-DD), currency and
+// rate, an optional header row is skipped.
+func DeserializeExchangeRatesFromCSV(r io.Reader) ([]ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && strings.EqualFold(records[0][0], "date") {
+		records = records[1:]
+	}
+
+	rates := make([]ExchangeRate, 0, len(records))
+	for i, record := range records {
+		date, err := time.ParseInLocation("2006-01-02", record[0], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w on row %d, date must be YYYY-MM-DD", ErrInvalidExchangeRate, i+1)
+		}
+		value, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w on row %d, rate must be a number", ErrInvalidExchangeRate, i+1)
+		}
+		rate := ExchangeRate{
+			Date:     date,
+			Currency: strings.ToUpper(record[1]),
+			Rate:     value,
+		}
+		err = rate.Validate()
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
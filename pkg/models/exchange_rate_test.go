@@ -0,0 +1,109 @@
+package models
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleExchangeRates() ExchangeRates {
+	return ExchangeRates{
+		Currency: "USD",
+		Rates: []ExchangeRate{
+			{Date: time.Date(2026, time.September, 15, 0, 0, 0, 0, time.UTC), Currency: "EUR", Rate: 1.2},
+			{Date: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), Currency: "EUR", Rate: 1.1},
+			{Date: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), Currency: "GBP", Rate: 1.3},
+		},
+	}
+}
+
+func TestExchangeRates_RateAt_UsesLatestRateUntilTheDate(t *testing.T) {
+	rates := sampleExchangeRates()
+
+	tests := []struct {
+		currency string
+		date     time.Time
+		expected float64
+	}{
+		{"EUR", time.Date(2026, time.September, 1, 9, 0, 0, 0, time.UTC), 1.1},
+		{"EUR", time.Date(2026, time.September, 14, 23, 0, 0, 0, time.UTC), 1.1},
+		{"eur", time.Date(2026, time.September, 15, 0, 0, 0, 0, time.UTC), 1.2},
+		{"GBP", time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), 1.3},
+		{"USD", time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), 1},
+	}
+	for _, test := range tests {
+		t.Run(test.currency+" "+test.date.String(), func(t *testing.T) {
+			rate, err := rates.RateAt(test.currency, test.date)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, rate)
+		})
+	}
+}
+
+func TestExchangeRates_RateAt_MissingRate(t *testing.T) {
+	rates := sampleExchangeRates()
+
+	_, err := rates.RateAt("EUR", time.Date(2026, time.August, 31, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, ErrNoExchangeRate)
+
+	_, err = rates.RateAt("JPY", time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, ErrNoExchangeRate)
+}
+
+func TestExchangeRates_ConvertEarnings(t *testing.T) {
+	rates := sampleExchangeRates()
+	session := sampleSession()
+	session.Start = time.Date(2026, time.September, 2, 9, 0, 0, 0, time.UTC)
+	session.End = session.Start.Add(2 * time.Hour)
+	session.Client = Client{Name: "Euro Client", PPH: 100, Currency: "EUR"}
+
+	converted, err := rates.ConvertEarnings(session)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 220.0, converted, 0.001)
+}
+
+func TestDeserializeExchangeRatesFromCSV(t *testing.T) {
+	rates, err := DeserializeExchangeRatesFromCSV(strings.NewReader(
+		"date,currency,rate\n2026-09-01,eur,1.1\n2026-09-15, EUR, 1.2\n"))
+
+	assert.NoError(t, err)
+	assert.Len(t, rates, 2)
+	assert.Equal(t, time.Date(2026, time.September, 1, 0, 0, 0, 0, time.Local), rates[0].Date)
+	assert.Equal(t, "EUR", rates[0].Currency)
+	assert.Equal(t, 1.2, rates[1].Rate)
+}
+
+func TestDeserializeExchangeRatesFromCSV_Invalid(t *testing.T) {
+	inputs := []string{
+		"2026-09-01,EUR\n",
+		"09/01/2026,EUR,1.1\n",
+		"2026-09-01,EUR,abc\n",
+		"2026-09-01,EUR,0\n",
+		"2026-09-01,EURO,1.1\n",
+	}
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := DeserializeExchangeRatesFromCSV(strings.NewReader(input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSerializeExchangeRatesToCSV_RoundTrips(t *testing.T) {
+	rates := []ExchangeRate{
+		{Date: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.Local), Currency: "EUR", Rate: 1.0825},
+	}
+	var buf bytes.Buffer
+
+	err := SerializeExchangeRatesToCSV(&buf, rates)
+	assert.NoError(t, err)
+	assert.Equal(t, "date,currency,rate\n2026-09-01,EUR,1.0825\n", buf.String())
+
+	deserialized, err := DeserializeExchangeRatesFromCSV(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, rates, deserialized)
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Invoice is an issued bill for a client's sessions within a period, its
+// number is sequential across all clients.
+type Invoice struct {
+	Number   uint32
+	Client   Client
+	IssuedAt time.Time
+	From     time.Time
+	To       time.Time
+}
+
+func (i Invoice) String() string {
+	return fmt.Sprintf("#%d\t%s\t%s\t%s - %s",
+		i.Number,
+		i.Client.Name,
+		i.IssuedAt.Format("2006-01-02"),
+		i.From.Format("2006-01-02"),
+		i.To.Format("2006-01-02"))
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"fmt"
+)
+
+type Project struct {
+	Name   string  `yaml:"name"`
+	Client Client  `yaml:"-"`
+	PPH    *uint16 `yaml:"pph,omitempty"`
+}
+
+// Rate returns the project's hourly rate, falling back to the client's rate
+// when the project does not override it.
+func (p Project) Rate() uint16 {
+	if p.PPH != nil {
+		return *p.PPH
+	}
+	return p.Client.PPH
+}
+
+func (p Project) Currency() string {
+	return p.Client.Currency
+}
+
+func (p Project) String() string {
+	return fmt.Sprintf("%s\t%s\t%d %s", p.Name, p.Client.Name, p.Rate(), p.Currency())
+}
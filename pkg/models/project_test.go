@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProject_Rate_InheritsClientRate(t *testing.T) {
+	project := Project{Name: "Website", Client: Client{Name: "Acme", PPH: 100, Currency: "USD"}}
+
+	assert.Equal(t, uint16(100), project.Rate())
+	assert.Equal(t, "USD", project.Currency())
+}
+
+func TestProject_Rate_OverridesClientRate(t *testing.T) {
+	rate := uint16(150)
+	project := Project{Name: "Website", Client: Client{Name: "Acme", PPH: 100, Currency: "USD"}, PPH: &rate}
+
+	assert.Equal(t, uint16(150), project.Rate())
+}
+
+func TestProject_String(t *testing.T) {
+	project := Project{Name: "Website", Client: Client{Name: "Acme", PPH: 100, Currency: "USD"}}
+
+	assert.Equal(t, "Website\tAcme\t100 USD", project.String())
+}
@@ -2,9 +2,13 @@ package models
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -15,12 +19,59 @@ const (
 )
 
 type EditableSession struct {
-	ID        string `yaml:"id"`
-	Client    string `yaml:"client"`
-	Date      string `yaml:"date"`
+	ID        string          `yaml:"id"`
+	Client    string          `yaml:"client"`
+	Project   string          `yaml:"project"`
+	Date      string          `yaml:"date"`
+	StartTime string          `yaml:"start_time"`
+	EndTime   string          `yaml:"end_time"`
+	Note      string          `yaml:"note"`
+	Tags      []string        `yaml:"tags,flow"`
+	Breaks    []EditableBreak `yaml:"breaks,omitempty"`
+}
+
+type EditableBreak struct {
 	StartTime string `yaml:"start_time"`
 	EndTime   string `yaml:"end_time"`
-	Note      string `yaml:"note"`
+}
+
+// ToBreak parses the break's times relative to the session start, breaks
+// whose time is earlier than the session start are considered to be on the
+// following day.
+func (eb EditableBreak) ToBreak(sessionStart time.Time) (*Break, error) {
+	date := sessionStart.Format("2006-01-02")
+	start, err := time.ParseInLocation("15:04:05 2006-01-02", eb.StartTime+" "+date, sessionStart.Location())
+	if err != nil {
+		return nil, err
+	}
+	if start.Before(sessionStart) {
+		start = start.AddDate(0, 0, 1)
+	}
+
+	var end time.Time
+	if eb.EndTime != "N/A" && eb.EndTime != "" {
+		end, err = time.ParseInLocation("15:04:05 2006-01-02", eb.EndTime+" "+start.Format("2006-01-02"), sessionStart.Location())
+		if err != nil {
+			return nil, err
+		}
+		if end.Before(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+	}
+
+	return &Break{Start: start, End: end}, nil
+}
+
+func parseEditableBreaks(editableBreaks []EditableBreak, sessionStart time.Time) ([]Break, error) {
+	var breaks []Break
+	for _, eb := range editableBreaks {
+		b, err := eb.ToBreak(sessionStart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid break for session: %v", err)
+		}
+		breaks = append(breaks, *b)
+	}
+	return breaks, nil
 }
 
 func (ed EditableSession) ToSession() (*Session, error) {
@@ -34,6 +85,10 @@ func (ed EditableSession) ToSession() (*Session, error) {
 	}
 
 	client := Client{Name: ed.Client}
+	var project *Project
+	if ed.Project != "" {
+		project = &Project{Name: ed.Project, Client: client}
+	}
 	startTime, err := time.ParseInLocation("15:04:05 2006-01-02", ed.StartTime+" "+ed.Date, time.Local)
 	if err != nil {
 		return nil, err
@@ -51,12 +106,20 @@ func (ed EditableSession) ToSession() (*Session, error) {
 		endTime = nextDayEndTime
 	}
 
+	breaks, err := parseEditableBreaks(ed.Breaks, startTime)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Session{
-		ID:     uintId,
-		Client: client,
-		Start:  startTime,
-		End:    endTime,
-		Note:   ed.Note,
+		ID:      uintId,
+		Client:  client,
+		Project: project,
+		Start:   startTime,
+		End:     endTime,
+		Note:    ed.Note,
+		Breaks:  breaks,
+		Tags:    NormalizeTags(ed.Tags),
 	}, nil
 }
 
@@ -65,6 +128,7 @@ func SerializeSessionsToYAML(sessions []Session) (*bytes.Buffer, error) {
 
 	buf.WriteString("# Change either the `start_time` or `end_time` fields to edit the day\n")
 	buf.WriteString("# The `id`, `client` and `date` fields are for reference only\n")
+	buf.WriteString("# Breaks and tags can be added, edited or removed under the `breaks` and `tags` fields\n")
 	buf.WriteString("\n")
 	for i, session := range sessions {
 		serialized, err := session.SerializeYAML()
@@ -160,9 +224,20 @@ func updateSession(ed EditableSession, session *Session) error {
 		endTime = endTime.AddDate(0, 0, 1)
 	}
 
+	breaks, err := parseEditableBreaks(ed.Breaks, startTime)
+	if err != nil {
+		return err
+	}
+
+	session.Project = nil
+	if ed.Project != "" {
+		session.Project = &Project{Name: ed.Project, Client: session.Client}
+	}
 	session.Start = startTime
 	session.End = endTime
 	session.Note = ed.Note
+	session.Breaks = breaks
+	session.Tags = NormalizeTags(ed.Tags)
 	return nil
 }
 
@@ -189,8 +264,12 @@ func SerializeSessionsToCSV(sessions []Session) (*bytes.Buffer, error) {
 
 func SerializeSessionsToFullCSV(session []Session) (*bytes.Buffer, error) {
 	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
 
-	buf.WriteString("id,date,client,start_time,end_time,duration,amount,currency,note\n")
+	err := writer.Write([]string{"id", "date", "client", "project", "start_time", "end_time", "breaks", "duration", "amount", "currency", "status", "tags", "note"})
+	if err != nil {
+		return nil, err
+	}
 	for _, session := range session {
 		id := fmt.Sprintf("%d", session.ID)
 
@@ -205,18 +284,195 @@ func SerializeSessionsToFullCSV(session []Session) (*bytes.Buffer, error) {
 			end = session.End.Format("15:04:05")
 		}
 
-		buf.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s,%s,%s\n",
+		// notes are quoted when needed, so they can be imported back
+		err = writer.Write([]string{
 			id,
 			session.Start.Format("2006-01-02"),
 			session.Client.Name,
+			session.ProjectName(),
 			session.Start.Format("15:04:05"),
 			end,
+			formatCSVBreaks(session.Breaks),
 			session.Duration(),
 			earningsString,
 			session.Client.Currency,
+			session.Status.String(),
+			strings.Join(NormalizeTags(session.Tags), ","),
 			session.Note,
-		))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+
+	return &buf, writer.Error()
+}
+
+// formatCSVBreaks lays out the breaks as start/end times separated by
+// semicolons, the end of a running break is left empty.
+func formatCSVBreaks(breaks []Break) string {
+	intervals := make([]string, 0, len(breaks))
+	for _, b := range breaks {
+		interval := b.Start.Format("15:04:05") + "/"
+		if b.End != NULL_TIME {
+			interval += b.End.Format("15:04:05")
+		}
+		intervals = append(intervals, interval)
+	}
+	return strings.Join(intervals, ";")
+}
+
+// JSONSession is the stable JSON schema of a session, times are ISO-8601 and
+// `end` is null for sessions that are still running.
+type JSONSession struct {
+	ID              uint32   `json:"id"`
+	Client          string   `json:"client"`
+	Project         string   `json:"project"`
+	Start           string   `json:"start"`
+	End             *string  `json:"end"`
+	DurationSeconds int64    `json:"duration_seconds"`
+	BillableSeconds int64    `json:"billable_seconds"`
+	BreakSeconds    int64    `json:"break_seconds"`
+	Earnings        float64  `json:"earnings"`
+	Currency        string   `json:"currency"`
+	Status          string   `json:"status"`
+	Tags            []string `json:"tags"`
+	Note            string   `json:"note"`
+}
+
+// JSONSessionSummary is the stable JSON schema of a summary, the duration and
+// earnings are totals of all the summarized sessions. The client is only set
+// when summarizing by client, and the reporting earnings only when a
+// reporting currency is configured.
+type JSONSessionSummary struct {
+	GroupBy           string   `json:"group_by"`
+	Group             string   `json:"group"`
+	Client            string   `json:"client,omitempty"`
+	LastDate          string   `json:"last_date"`
+	DurationSeconds   int64    `json:"duration_seconds"`
+	BillableSeconds   int64    `json:"billable_seconds"`
+	Earnings          float64  `json:"earnings"`
+	Currency          string   `json:"currency"`
+	ReportingEarnings *float64 `json:"reporting_earnings,omitempty"`
+	ReportingCurrency string   `json:"reporting_currency,omitempty"`
+}
+
+// JSONClient is the stable JSON schema of a client.
+type JSONClient struct {
+	Name     string `json:"name"`
+	PPH      uint16 `json:"pph"`
+	Currency string `json:"currency"`
+}
+
+func (s Session) ToJSON() JSONSession {
+	var end *string
+	if s.Finished() {
+		formatted := s.End.Format(time.RFC3339)
+		end = &formatted
+	}
+	earnings, _ := s.Earnings()
+	return JSONSession{
+		ID:              s.ID,
+		Client:          s.Client.Name,
+		Project:         s.ProjectName(),
+		Start:           s.Start.Format(time.RFC3339),
+		End:             end,
+		DurationSeconds: int64(s.WorkDuration().Seconds()),
+		BillableSeconds: int64(s.BillableDuration().Seconds()),
+		BreakSeconds:    int64(s.BreakDuration().Seconds()),
+		Earnings:        roundCents(earnings),
+		Currency:        s.Client.Currency,
+		Status:          s.Status.String(),
+		Tags:            NormalizeTags(s.Tags),
+		Note:            s.Note,
+	}
+}
+
+func (c Client) ToJSON() JSONClient {
+	return JSONClient{
+		Name:     c.Name,
+		PPH:      c.PPH,
+		Currency: c.Currency,
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// SerializeSummariesToCSV writes a row per summary, named after what the
+// sessions were grouped by. The converted amount is added when a reporting
+// currency is given.
+func SerializeSummariesToCSV(summaries []SessionSummary, groupBy SummaryGroupBy, reportingCurrency string) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{string(groupBy), "last_date", "duration", "billable", "amount", "currency"}
+	if reportingCurrency != "" {
+		header = append(header, "reporting_amount", "reporting_currency")
+	}
+	err := writer.Write(header)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		row := []string{
+			summary.Group,
+			summary.LastDate.Format("2006-01-02"),
+			FormatDuration(summary.Duration),
+			FormatDuration(summary.Billable),
+			fmt.Sprintf("%.2f", summary.Earnings),
+			summary.Currency,
+		}
+		if reportingCurrency != "" {
+			row = append(row, fmt.Sprintf("%.2f", summary.Converted), reportingCurrency)
+		}
+		err = writer.Write(row)
+		if err != nil {
+			return nil, err
+		}
 	}
+	writer.Flush()
+	return &buf, writer.Error()
+}
 
+// SerializeToJSON encodes the items as a JSON array, or as newline delimited
+// JSON objects when ndjson is set.
+func SerializeToJSON[T any](items []T, ndjson bool) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	if !ndjson {
+		if items == nil {
+			items = []T{}
+		}
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(items)
+		if err != nil {
+			return nil, err
+		}
+		return &buf, nil
+	}
+	for _, item := range items {
+		err := encoder.Encode(item)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &buf, nil
 }
+
+func SerializeSessionsToJSON(sessions []Session, ndjson bool) (*bytes.Buffer, error) {
+	jsonSessions := make([]JSONSession, 0, len(sessions))
+	for _, session := range sessions {
+		jsonSessions = append(jsonSessions, session.ToJSON())
+	}
+	return SerializeToJSON(jsonSessions, ndjson)
+}
+
+func SerializeClientsToJSON(clients []Client, ndjson bool) (*bytes.Buffer, error) {
+	jsonClients := make([]JSONClient, 0, len(clients))
+	for _, client := range clients {
+		jsonClients = append(jsonClients, client.ToJSON())
+	}
+	return SerializeToJSON(jsonClients, ndjson)
+}
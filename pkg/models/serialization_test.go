@@ -2,7 +2,9 @@ package models
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -114,6 +116,66 @@ func TestSerializeSessionsToFullCSV_ValidSessions(t *testing.T) {
 	assert.Contains(t, buf.String(), sessions[0].Client.Name)
 }
 
+func TestSerializeSessionsToFullCSV_IncludesStatus(t *testing.T) {
+	session := sampleSession()
+	session.Status = SESSION_STATUS_PAID
+	buf, err := SerializeSessionsToFullCSV([]Session{session})
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), ",status,")
+	assert.Contains(t, buf.String(), ",paid,")
+}
+
+func TestSession_ToJSON_FinishedSession(t *testing.T) {
+	session := sampleSession()
+	session.Tags = []string{"dev"}
+	jsonSession := session.ToJSON()
+
+	assert.Equal(t, uint32(1), jsonSession.ID)
+	assert.Equal(t, "2022-01-01T09:00:00Z", jsonSession.Start)
+	assert.Equal(t, "2022-01-01T11:00:00Z", *jsonSession.End)
+	assert.Equal(t, int64(7200), jsonSession.DurationSeconds)
+	assert.InDelta(t, 84138.0, jsonSession.Earnings, 0.001)
+	assert.Equal(t, "unbilled", jsonSession.Status)
+	assert.Equal(t, []string{"dev"}, jsonSession.Tags)
+}
+
+func TestSession_ToJSON_RunningSessionHasNullEnd(t *testing.T) {
+	session := sampleSession()
+	session.End = NULL_TIME
+	buf, err := SerializeSessionsToJSON([]Session{session}, true)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `"end":null`)
+	assert.Contains(t, buf.String(), `"tags":[]`)
+}
+
+func TestSerializeSessionsToJSON_NDJSONLinePerSession(t *testing.T) {
+	sessions := []Session{sampleSession(), sampleSession()}
+	buf, err := SerializeSessionsToJSON(sessions, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+}
+
+func TestSerializeClientsToJSON_Array(t *testing.T) {
+	clients := []Client{{Name: "Acme", PPH: 100, Currency: "USD"}}
+	buf, err := SerializeClientsToJSON(clients, false)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"Acme","pph":100,"currency":"USD"}]`, buf.String())
+}
+
+func TestSerializeSummariesToCSV(t *testing.T) {
+	summaries := summarize(t, []Session{sampleSession()}, SUMMARY_GROUP_BY_DAY, time.Sunday)
+
+	buf, err := SerializeSummariesToCSV(summaries, SUMMARY_GROUP_BY_DAY, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "day,last_date,duration,billable,amount,currency\n"+
+		"2022-01-01,2022-01-01,02:00:00,02:00:00,84138.00,USD\n", buf.String())
+}
+
 func TestDeserializeSessionsFromYAML_EmptyYAML(t *testing.T) {
 	buf := bytes.NewBufferString("")
 	deserializedSessions, err := DeserializeSessionsFromYAML(buf)
@@ -164,3 +226,38 @@ func TestSerializeSessionsToYAML_EmptySessions(t *testing.T) {
 	assert.NotNil(t, buf)
 	assert.Contains(t, buf.String(), "# Change either the `start_time` or `end_time` fields to edit the day\n", "YAML should contain headers even for empty session list")
 }
+
+func TestDeserializeSessionsFromYAML_WithBreaks(t *testing.T) {
+	session := sampleSession()
+	session.Start = session.Start.In(time.Local)
+	session.End = session.End.In(time.Local)
+	session.Breaks = []Break{
+		{Start: session.Start.Add(30 * time.Minute), End: session.Start.Add(45 * time.Minute)},
+	}
+	buf, err := SerializeSessionsToYAML([]Session{session})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "breaks:")
+
+	deserializedSessions, err := DeserializeSessionsFromYAML(buf)
+	assert.NoError(t, err)
+	assert.Len(t, *deserializedSessions, 1)
+	assert.True(t, session.Equals((*deserializedSessions)[0]), "Breaks should survive a round trip")
+}
+
+func TestEditableBreak_ToBreak_AfterMidnight(t *testing.T) {
+	sessionStart := time.Date(2022, time.January, 1, 22, 0, 0, 0, time.UTC)
+	eb := EditableBreak{StartTime: "23:30:00", EndTime: "00:15:00"}
+
+	b, err := eb.ToBreak(sessionStart)
+	assert.NoError(t, err)
+	assert.Equal(t, 45*time.Minute, b.End.Sub(b.Start))
+}
+
+func TestEditableBreak_ToBreak_OpenBreak(t *testing.T) {
+	sessionStart := time.Date(2022, time.January, 1, 9, 0, 0, 0, time.UTC)
+	eb := EditableBreak{StartTime: "12:00:00", EndTime: "N/A"}
+
+	b, err := eb.ToBreak(sessionStart)
+	assert.NoError(t, err)
+	assert.False(t, b.Finished())
+}
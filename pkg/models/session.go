@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -11,12 +13,31 @@ var (
 	NULL_TIME = time.Time{}
 )
 
+// Session is a period of work for a client. Its ID is only unique within the
+// local database, while its UUID identifies it across devices.
 type Session struct {
-	ID     uint32
-	Client Client
-	Start  time.Time
-	End    time.Time
-	Note   string
+	ID            uint32
+	UUID          string
+	Client        Client
+	Project       *Project
+	Start         time.Time
+	End           time.Time
+	Note          string
+	Breaks        []Break
+	Tags          []string
+	InvoiceNumber uint32
+	Status        SessionStatus
+}
+
+// Invoiced reports whether the session was billed in an invoice.
+func (s Session) Invoiced() bool {
+	return s.InvoiceNumber != 0
+}
+
+// Locked reports whether the session was invoiced or paid and should not be
+// modified anymore.
+func (s Session) Locked() bool {
+	return s.Status.Locked()
 }
 
 func (s Session) Matches(session Session) bool {
@@ -42,7 +63,68 @@ func (s Session) Equals(session Session) bool {
 		s.Start.Equal(session.Start) &&
 		s.End.Equal(session.End) &&
 		s.Client.Name == session.Client.Name &&
-		s.Note == session.Note
+		s.ProjectName() == session.ProjectName() &&
+		s.Note == session.Note &&
+		equalBreaks(s.Breaks, session.Breaks) &&
+		slices.Equal(NormalizeTags(s.Tags), NormalizeTags(session.Tags))
+}
+
+func equalBreaks(a, b []Break) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Start.Equal(b[i].Start) || !a[i].End.Equal(b[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Session) ProjectName() string {
+	if s.Project == nil {
+		return ""
+	}
+	return s.Project.Name
+}
+
+// Rate is the hourly rate the session is billed at, a project's rate takes
+// precedence over the client's rate in force when the session started.
+func (s Session) Rate() uint16 {
+	if s.Project != nil && s.Project.PPH != nil {
+		return *s.Project.PPH
+	}
+	return s.Client.RateAt(s.Start)
+}
+
+func (s Session) HasTag(tag string) bool {
+	for _, t := range s.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTags trims, deduplicates (case insensitive) and sorts tags.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if slices.ContainsFunc(normalized, func(t string) bool {
+			return strings.EqualFold(t, tag)
+		}) {
+			continue
+		}
+		normalized = append(normalized, tag)
+	}
+	slices.SortFunc(normalized, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return normalized
 }
 
 func (s Session) Conflicts(session Session) bool {
@@ -70,23 +152,68 @@ func (s Session) Finished() bool {
 	return !s.End.Equal(NULL_TIME)
 }
 
-func (s Session) Earnings() (float64, error) {
-	if s.Start.Equal(NULL_TIME) {
-		return 0, fmt.Errorf("Session not started or ended")
+// Overlaps reports whether both sessions were running at the same time, a
+// session that is still running lasts indefinitely.
+func (s Session) Overlaps(session Session) bool {
+	return (!session.Finished() || s.Start.Before(session.End)) &&
+		(!s.Finished() || session.Start.Before(s.End))
+}
+
+// OverlappingPair holds two sessions that were running at the same time,
+// First is the one that started earlier.
+type OverlappingPair struct {
+	First  Session
+	Second Session
+}
+
+// FindOverlaps lists every pair of overlapping sessions, ordered by start. When
+// sameClientOnly is set, sessions of different clients never overlap.
+func FindOverlaps(sessions []Session, sameClientOnly bool) []OverlappingPair {
+	sorted := slices.Clone(sessions)
+	slices.SortStableFunc(sorted, func(a, b Session) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	var pairs []OverlappingPair
+	for i, first := range sorted {
+		for _, second := range sorted[i+1:] {
+			if first.Finished() && !second.Start.Before(first.End) {
+				break
+			}
+			if sameClientOnly && first.Client.Name != second.Client.Name {
+				continue
+			}
+			pairs = append(pairs, OverlappingPair{First: first, Second: second})
+		}
+	}
+	return pairs
+}
+
+func (s Session) Paused() bool {
+	if len(s.Breaks) == 0 {
+		return false
 	}
+	return !s.Breaks[len(s.Breaks)-1].Finished()
+}
+
+// BreakDuration sums up all breaks taken during the session, an open break
+// counts up until the session's end (or now, if the session is still running).
+func (s Session) BreakDuration() time.Duration {
 	end := time.Now()
-	if s.End != NULL_TIME {
+	if s.Finished() {
 		end = s.End
 	}
-	delta := end.Sub(s.Start)
-	hours := delta.Hours()
-	value := float64(s.Client.PPH) * hours
-	return value, nil
+	var total time.Duration
+	for _, b := range s.Breaks {
+		total += b.Until(end)
+	}
+	return total
 }
 
-func (s Session) Duration() string {
+// WorkDuration is the time between start and end with all breaks subtracted.
+func (s Session) WorkDuration() time.Duration {
 	if s.Start.Equal(NULL_TIME) {
-		return "N/A"
+		return 0
 	}
 
 	end := time.Now()
@@ -98,18 +225,46 @@ func (s Session) Duration() string {
 		end = end.AddDate(0, 0, 1)
 	}
 
-	delta := end.Sub(s.Start)
-	hours := int(delta.Hours())
-	minutes := int(delta.Minutes()) % 60
-	seconds := int(delta.Seconds()) % 60
+	delta := end.Sub(s.Start) - s.BreakDuration()
+	if delta < 0 {
+		return 0
+	}
+	return delta
+}
 
-	returnValue := fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+// BillableDuration is the work duration rounded by the client's rules.
+func (s Session) BillableDuration() time.Duration {
+	return s.Client.Rounding.Apply(s.WorkDuration())
+}
+
+func (s Session) Earnings() (float64, error) {
+	if s.Start.Equal(NULL_TIME) {
+		return 0, fmt.Errorf("Session not started or ended")
+	}
+	hours := s.BillableDuration().Hours()
+	value := float64(s.Rate()) * hours
+	return value, nil
+}
+
+func (s Session) Duration() string {
+	if s.Start.Equal(NULL_TIME) {
+		return "N/A"
+	}
+
+	returnValue := FormatDuration(s.WorkDuration())
 	if s.End.Equal(NULL_TIME) {
 		returnValue = "~" + returnValue
 	}
 	return returnValue
 }
 
+func FormatDuration(delta time.Duration) string {
+	hours := int(delta.Hours())
+	minutes := int(delta.Minutes()) % 60
+	seconds := int(delta.Seconds()) % 60
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
+
 func (s Session) SerializeYAML() (*[]byte, error) {
 	id := fmt.Sprint(s.ID)
 	startDate := "N/A"
@@ -122,13 +277,27 @@ func (s Session) SerializeYAML() (*[]byte, error) {
 	if s.End != NULL_TIME {
 		end = s.End.Format("15:04:05")
 	}
+	var breaks []EditableBreak
+	for _, b := range s.Breaks {
+		breakEnd := "N/A"
+		if b.Finished() {
+			breakEnd = b.End.Format("15:04:05")
+		}
+		breaks = append(breaks, EditableBreak{
+			StartTime: b.Start.Format("15:04:05"),
+			EndTime:   breakEnd,
+		})
+	}
 	ed := EditableSession{
 		ID:        id,
 		Client:    s.Client.Name,
+		Project:   s.ProjectName(),
 		Date:      startDate,
 		StartTime: startTime,
 		EndTime:   end,
 		Note:      s.Note,
+		Tags:      NormalizeTags(s.Tags),
+		Breaks:    breaks,
 	}
 
 	data, err := yaml.Marshal(ed)
@@ -180,3 +180,203 @@ func TestSession_SerializeYAML_WithNilEnd(t *testing.T) {
 	assert.NoError(t, err, "Serialization should not produce an error with nil End")
 	assert.NotNil(t, data, "Serialized data should not be nil")
 }
+
+func TestSession_WorkDuration_SubtractsBreaks(t *testing.T) {
+	session := sampleSession()
+	session.Breaks = []Break{
+		{Start: session.Start.Add(30 * time.Minute), End: session.Start.Add(45 * time.Minute)},
+		{Start: session.Start.Add(time.Hour), End: session.Start.Add(90 * time.Minute)},
+	}
+
+	assert.Equal(t, 45*time.Minute, session.BreakDuration())
+	assert.Equal(t, 75*time.Minute, session.WorkDuration())
+	assert.Equal(t, "01:15:00", session.Duration())
+}
+
+func TestSession_Earnings_SubtractsBreaks(t *testing.T) {
+	session := sampleSession()
+	session.Client.PPH = 100
+	session.Breaks = []Break{
+		{Start: session.Start.Add(time.Hour), End: session.Start.Add(90 * time.Minute)},
+	}
+
+	earnings, err := session.Earnings()
+	assert.NoError(t, err)
+	assert.InDelta(t, 150.0, earnings, 0.001, "Earnings should not include the break")
+}
+
+func TestSession_Paused_OpenBreak(t *testing.T) {
+	session := sampleSession()
+	session.End = NULL_TIME
+	session.Breaks = []Break{{Start: session.Start.Add(time.Hour)}}
+
+	assert.True(t, session.Paused(), "Session with an open break should be paused")
+}
+
+func TestSession_Paused_FinishedBreaks(t *testing.T) {
+	session := sampleSession()
+	session.Breaks = []Break{
+		{Start: session.Start.Add(time.Hour), End: session.Start.Add(90 * time.Minute)},
+	}
+
+	assert.False(t, session.Paused(), "Session with only finished breaks should not be paused")
+}
+
+func TestSession_Equals_DifferentBreaks(t *testing.T) {
+	session1 := sampleSession()
+	session2 := sampleSession()
+	session2.Breaks = []Break{
+		{Start: session2.Start.Add(time.Hour), End: session2.Start.Add(90 * time.Minute)},
+	}
+
+	assert.False(t, session1.Equals(session2), "Should return false if breaks are different")
+}
+
+func TestNormalizeTags_DeduplicatesAndSorts(t *testing.T) {
+	tags := NormalizeTags([]string{"support", " dev ", "Dev", "", "meetings"})
+
+	assert.Equal(t, []string{"dev", "meetings", "support"}, tags)
+}
+
+func TestSession_HasTag_CaseInsensitive(t *testing.T) {
+	session := sampleSession()
+	session.Tags = []string{"Meetings"}
+
+	assert.True(t, session.HasTag("meetings"))
+	assert.False(t, session.HasTag("dev"))
+}
+
+func TestSession_Equals_DifferentTags(t *testing.T) {
+	session1 := sampleSession()
+	session2 := sampleSession()
+	session2.Tags = []string{"dev"}
+
+	assert.False(t, session1.Equals(session2), "Should return false if tags are different")
+}
+
+func TestSession_Equals_TagsInDifferentOrder(t *testing.T) {
+	session1 := sampleSession()
+	session1.Tags = []string{"dev", "meetings"}
+	session2 := sampleSession()
+	session2.Tags = []string{"meetings", "dev"}
+
+	assert.True(t, session1.Equals(session2), "Tag order should not matter")
+}
+
+func TestSession_Earnings_UsesProjectRate(t *testing.T) {
+	session := sampleSession()
+	session.Client.PPH = 100
+	rate := uint16(150)
+	session.Project = &Project{Name: "Website", Client: session.Client, PPH: &rate}
+
+	earnings, err := session.Earnings()
+	assert.NoError(t, err)
+	assert.InDelta(t, 300.0, earnings, 0.001, "Earnings should use the project's rate")
+}
+
+func TestSession_Earnings_ProjectWithoutRateUsesClientRate(t *testing.T) {
+	session := sampleSession()
+	session.Client.PPH = 100
+	session.Project = &Project{Name: "Website", Client: session.Client}
+
+	earnings, err := session.Earnings()
+	assert.NoError(t, err)
+	assert.InDelta(t, 200.0, earnings, 0.001, "Earnings should fall back to the client's rate")
+}
+
+func TestSession_Equals_DifferentProjects(t *testing.T) {
+	session1 := sampleSession()
+	session2 := sampleSession()
+	session2.Project = &Project{Name: "Website", Client: session2.Client}
+
+	assert.False(t, session1.Equals(session2), "Should return false if projects are different")
+}
+
+func TestSession_Earnings_UsesRateAtSessionStart(t *testing.T) {
+	session := sampleSession()
+	session.Client.PPH = 200
+	session.Client.Rates = []HourlyRate{
+		{PPH: 100},
+		{EffectiveFrom: session.Start.AddDate(0, 1, 0), PPH: 200},
+	}
+
+	earnings, err := session.Earnings()
+	assert.NoError(t, err)
+	assert.InDelta(t, 200.0, earnings, 0.001, "Earnings should use the rate effective at the session start")
+}
+
+func TestSession_Overlaps(t *testing.T) {
+	session := sampleSession()
+	tests := []struct {
+		name     string
+		start    time.Duration
+		end      time.Duration
+		open     bool
+		overlaps bool
+	}{
+		{"contained", 30 * time.Minute, time.Hour, false, true},
+		{"starts before", -time.Hour, time.Hour, false, true},
+		{"ends right at start", -time.Hour, 0, false, false},
+		{"starts right at end", 2 * time.Hour, 3 * time.Hour, false, false},
+		{"later", 3 * time.Hour, 4 * time.Hour, false, false},
+		{"open before end", time.Hour, 0, true, true},
+		{"open after end", 2 * time.Hour, 0, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			other := sampleSession()
+			other.ID = 2
+			other.Start = session.Start.Add(test.start)
+			other.End = session.Start.Add(test.end)
+			if test.open {
+				other.End = NULL_TIME
+			}
+
+			assert.Equal(t, test.overlaps, session.Overlaps(other))
+			assert.Equal(t, test.overlaps, other.Overlaps(session))
+		})
+	}
+}
+
+func TestFindOverlaps_OrdersPairsAndHonoursClients(t *testing.T) {
+	first := sampleSession()
+	second := sampleSession()
+	second.ID = 2
+	second.Start = first.Start.Add(time.Hour)
+	second.End = first.End.Add(time.Hour)
+	other := sampleSession()
+	other.ID = 3
+	other.Client = Client{Name: "Other Client"}
+	other.Start = first.Start.Add(30 * time.Minute)
+	later := sampleSession()
+	later.ID = 4
+	later.Start = first.End.Add(time.Hour)
+	later.End = later.Start.Add(time.Hour)
+
+	pairs := FindOverlaps([]Session{later, second, other, first}, true)
+
+	assert.Equal(t, []OverlappingPair{{First: first, Second: second}}, pairs)
+
+	pairs = FindOverlaps([]Session{later, second, other, first}, false)
+
+	assert.Len(t, pairs, 3)
+	assert.Equal(t, uint32(1), pairs[0].First.ID)
+	assert.Equal(t, uint32(3), pairs[0].Second.ID)
+	assert.Equal(t, uint32(3), pairs[2].First.ID)
+	assert.Equal(t, uint32(2), pairs[2].Second.ID)
+}
+
+func TestSession_Earnings_UsesBillableDuration(t *testing.T) {
+	session := sampleSession()
+	session.Client.PPH = 60
+	session.Client.Rounding = Rounding{Increment: 15, Mode: ROUNDING_MODE_UP}
+	session.End = session.Start.Add(61 * time.Minute)
+
+	earnings, err := session.Earnings()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 61*time.Minute, session.WorkDuration(), "Raw duration should be untouched")
+	assert.Equal(t, 75*time.Minute, session.BillableDuration())
+	assert.InDelta(t, 75.0, earnings, 0.001)
+}
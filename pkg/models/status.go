@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SessionStatus is the billing status of a session, invoiced and paid
+// sessions are locked from modifications.
+type SessionStatus string
+
+const (
+	SESSION_STATUS_UNBILLED SessionStatus = ""
+	SESSION_STATUS_INVOICED SessionStatus = "invoiced"
+	SESSION_STATUS_PAID     SessionStatus = "paid"
+)
+
+func (s SessionStatus) Locked() bool {
+	return s == SESSION_STATUS_INVOICED || s == SESSION_STATUS_PAID
+}
+
+func (s SessionStatus) String() string {
+	if s == SESSION_STATUS_UNBILLED {
+		return "unbilled"
+	}
+	return string(s)
+}
+
+func ParseSessionStatus(status string) (SessionStatus, error) {
+	switch strings.ToLower(status) {
+	case "unbilled":
+		return SESSION_STATUS_UNBILLED, nil
+	case string(SESSION_STATUS_INVOICED):
+		return SESSION_STATUS_INVOICED, nil
+	case string(SESSION_STATUS_PAID):
+		return SESSION_STATUS_PAID, nil
+	}
+	return SESSION_STATUS_UNBILLED, fmt.Errorf("invalid status `%s`, must be one of: unbilled, invoiced, paid", status)
+}
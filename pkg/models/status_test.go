@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionStatus_Locked(t *testing.T) {
+	assert.False(t, SESSION_STATUS_UNBILLED.Locked())
+	assert.True(t, SESSION_STATUS_INVOICED.Locked())
+	assert.True(t, SESSION_STATUS_PAID.Locked())
+}
+
+func TestSessionStatus_String(t *testing.T) {
+	assert.Equal(t, "unbilled", SESSION_STATUS_UNBILLED.String())
+	assert.Equal(t, "paid", SESSION_STATUS_PAID.String())
+}
+
+func TestParseSessionStatus_Valid(t *testing.T) {
+	status, err := ParseSessionStatus("Invoiced")
+	assert.NoError(t, err)
+	assert.Equal(t, SESSION_STATUS_INVOICED, status)
+
+	status, err = ParseSessionStatus("unbilled")
+	assert.NoError(t, err)
+	assert.Equal(t, SESSION_STATUS_UNBILLED, status)
+}
+
+func TestParseSessionStatus_Invalid(t *testing.T) {
+	_, err := ParseSessionStatus("overdue")
+	assert.Error(t, err)
+}
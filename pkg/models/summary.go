@@ -0,0 +1,204 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+type SummaryGroupBy string
+
+const (
+	SUMMARY_GROUP_BY_CLIENT   SummaryGroupBy = "client"
+	SUMMARY_GROUP_BY_DAY      SummaryGroupBy = "day"
+	SUMMARY_GROUP_BY_WEEK     SummaryGroupBy = "week"
+	SUMMARY_GROUP_BY_MONTH    SummaryGroupBy = "month"
+	SUMMARY_GROUP_BY_NOTE     SummaryGroupBy = "note"
+	SUMMARY_GROUP_BY_CURRENCY SummaryGroupBy = "currency"
+)
+
+var ErrInvalidSummaryGroupBy = errors.New("invalid group by, must be one of client, day, week, month, note or currency")
+
+func ParseSummaryGroupBy(groupBy string) (SummaryGroupBy, error) {
+	switch SummaryGroupBy(strings.ToLower(groupBy)) {
+	case SUMMARY_GROUP_BY_CLIENT:
+		return SUMMARY_GROUP_BY_CLIENT, nil
+	case SUMMARY_GROUP_BY_DAY:
+		return SUMMARY_GROUP_BY_DAY, nil
+	case SUMMARY_GROUP_BY_WEEK:
+		return SUMMARY_GROUP_BY_WEEK, nil
+	case SUMMARY_GROUP_BY_MONTH:
+		return SUMMARY_GROUP_BY_MONTH, nil
+	case SUMMARY_GROUP_BY_NOTE:
+		return SUMMARY_GROUP_BY_NOTE, nil
+	case SUMMARY_GROUP_BY_CURRENCY:
+		return SUMMARY_GROUP_BY_CURRENCY, nil
+	}
+	return "", ErrInvalidSummaryGroupBy
+}
+
+// SessionSummary sums up the sessions of a group. Earnings are never added
+// up across currencies, a group with sessions in several currencies is
+// summarized once per currency. Converted holds the earnings in the reporting
+// currency when the sessions were summarized with exchange rates.
+type SessionSummary struct {
+	Group     string
+	Currency  string
+	LastDate  time.Time
+	Duration  time.Duration
+	Billable  time.Duration
+	Earnings  float64
+	Converted float64
+}
+
+func (s *SessionSummary) add(session Session, rates *ExchangeRates) error {
+	s.Duration += session.WorkDuration()
+	s.Billable += session.BillableDuration()
+	earnings, err := session.Earnings()
+	if err == nil {
+		s.Earnings += earnings
+		if rates != nil {
+			converted, err := rates.Convert(earnings, session.Client.Currency, session.Start)
+			if err != nil {
+				return err
+			}
+			s.Converted += converted
+		}
+	}
+	if session.Start.After(s.LastDate) {
+		s.LastDate = session.Start
+	}
+	return nil
+}
+
+// SummaryGroup returns the group of a session, dates are formatted so that
+// groups sort chronologically. Weeks are named after the day they start on.
+func SummaryGroup(session Session, groupBy SummaryGroupBy, weekStart time.Weekday) string {
+	switch groupBy {
+	case SUMMARY_GROUP_BY_DAY:
+		return session.Start.Format("2006-01-02")
+	case SUMMARY_GROUP_BY_WEEK:
+		delta := (int(session.Start.Weekday()) - int(weekStart) + 7) % 7
+		return session.Start.AddDate(0, 0, -delta).Format("2006-01-02")
+	case SUMMARY_GROUP_BY_MONTH:
+		return session.Start.Format("2006-01")
+	case SUMMARY_GROUP_BY_NOTE:
+		if session.Note == "" {
+			return "<no note>"
+		}
+		return session.Note
+	case SUMMARY_GROUP_BY_CURRENCY:
+		return session.Client.Currency
+	}
+	return session.Client.Name
+}
+
+// SummarizeSessions sums up the sessions per group and currency, ordered by
+// group and then by currency. Earnings are also converted when exchange rates
+// are given, using the rate in force when each session started.
+func SummarizeSessions(sessions []Session, groupBy SummaryGroupBy, weekStart time.Weekday, rates *ExchangeRates) ([]SessionSummary, error) {
+	type summaryKey struct {
+		group    string
+		currency string
+	}
+	var summaries []SessionSummary
+	indexes := make(map[summaryKey]int)
+	for _, session := range sessions {
+		key := summaryKey{
+			group:    SummaryGroup(session, groupBy, weekStart),
+			currency: session.Client.Currency,
+		}
+		i, ok := indexes[key]
+		if !ok {
+			summaries = append(summaries, SessionSummary{Group: key.group, Currency: key.currency})
+			i = len(summaries) - 1
+			indexes[key] = i
+		}
+		err := summaries[i].add(session, rates)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sortSummaries(summaries)
+	return summaries, nil
+}
+
+// SummaryTotals adds up the summaries per currency, ordered by currency.
+func SummaryTotals(summaries []SessionSummary) []SessionSummary {
+	var totals []SessionSummary
+	indexes := make(map[string]int)
+	for _, summary := range summaries {
+		i, ok := indexes[summary.Currency]
+		if !ok {
+			totals = append(totals, SessionSummary{Group: "<all>", Currency: summary.Currency})
+			i = len(totals) - 1
+			indexes[summary.Currency] = i
+		}
+		totals[i].Duration += summary.Duration
+		totals[i].Billable += summary.Billable
+		totals[i].Earnings += summary.Earnings
+		totals[i].Converted += summary.Converted
+		if summary.LastDate.After(totals[i].LastDate) {
+			totals[i].LastDate = summary.LastDate
+		}
+	}
+	sortSummaries(totals)
+	return totals
+}
+
+// SummaryGrandTotal adds up all the summaries, its earnings are the converted
+// earnings in the reporting currency.
+func SummaryGrandTotal(summaries []SessionSummary, reportingCurrency string) SessionSummary {
+	total := SessionSummary{Group: "<all>", Currency: reportingCurrency}
+	for _, summary := range summaries {
+		total.Duration += summary.Duration
+		total.Billable += summary.Billable
+		total.Earnings += summary.Converted
+		total.Converted += summary.Converted
+		if summary.LastDate.After(total.LastDate) {
+			total.LastDate = summary.LastDate
+		}
+	}
+	return total
+}
+
+func sortSummaries(summaries []SessionSummary) {
+	slices.SortStableFunc(summaries, func(a, b SessionSummary) int {
+		if a.Group != b.Group {
+			return strings.Compare(a.Group, b.Group)
+		}
+		return strings.Compare(a.Currency, b.Currency)
+	})
+}
+
+// ToJSON returns the summary's JSON schema, along with its converted earnings
+// when a reporting currency is given.
+func (s SessionSummary) ToJSON(groupBy SummaryGroupBy, reportingCurrency string) JSONSessionSummary {
+	summary := JSONSessionSummary{
+		GroupBy:         string(groupBy),
+		Group:           s.Group,
+		LastDate:        s.LastDate.Format("2006-01-02"),
+		DurationSeconds: int64(s.Duration.Seconds()),
+		BillableSeconds: int64(s.Billable.Seconds()),
+		Earnings:        roundCents(s.Earnings),
+		Currency:        s.Currency,
+	}
+	if groupBy == SUMMARY_GROUP_BY_CLIENT {
+		summary.Client = s.Group
+	}
+	if reportingCurrency != "" {
+		converted := roundCents(s.Converted)
+		summary.ReportingEarnings = &converted
+		summary.ReportingCurrency = reportingCurrency
+	}
+	return summary
+}
+
+func SummariesToJSON(summaries []SessionSummary, groupBy SummaryGroupBy, reportingCurrency string) []JSONSessionSummary {
+	jsonSummaries := make([]JSONSessionSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		jsonSummaries = append(jsonSummaries, summary.ToJSON(groupBy, reportingCurrency))
+	}
+	return jsonSummaries
+}
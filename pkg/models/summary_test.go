@@ -0,0 +1,137 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func summarize(t *testing.T, sessions []Session, groupBy SummaryGroupBy, weekStart time.Weekday) []SessionSummary {
+	summaries, err := SummarizeSessions(sessions, groupBy, weekStart, nil)
+	assert.NoError(t, err)
+	return summaries
+}
+
+func TestParseSummaryGroupBy(t *testing.T) {
+	groupBy, err := ParseSummaryGroupBy("Week")
+	assert.NoError(t, err)
+	assert.Equal(t, SUMMARY_GROUP_BY_WEEK, groupBy)
+
+	_, err = ParseSummaryGroupBy("tag")
+	assert.ErrorIs(t, err, ErrInvalidSummaryGroupBy)
+}
+
+func TestSummarizeSessions_ByClientSortedByClient(t *testing.T) {
+	acme := sampleSession()
+	acme.Client = Client{Name: "Acme", PPH: 100, Currency: "USD"}
+	other := sampleSession()
+	summaries := summarize(t, []Session{other, acme, acme}, SUMMARY_GROUP_BY_CLIENT, time.Sunday)
+
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, "Acme", summaries[0].Group)
+	assert.Equal(t, 4*time.Hour, summaries[0].Duration)
+	assert.InDelta(t, 400.0, summaries[0].Earnings, 0.001)
+	assert.Equal(t, acme.Start, summaries[0].LastDate)
+	assert.Equal(t, "Test Client", summaries[1].Group)
+}
+
+func TestSummarizeSessions_SplitsGroupsPerCurrency(t *testing.T) {
+	usd := sampleSession()
+	eur := sampleSession()
+	eur.Client = Client{Name: "Euro Client", PPH: 100, Currency: "EUR"}
+
+	summaries := summarize(t, []Session{usd, eur}, SUMMARY_GROUP_BY_DAY, time.Sunday)
+
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, "2022-01-01", summaries[0].Group)
+	assert.Equal(t, "EUR", summaries[0].Currency)
+	assert.Equal(t, "2022-01-01", summaries[1].Group)
+	assert.Equal(t, "USD", summaries[1].Currency)
+}
+
+func TestSummarizeSessions_GroupsByPeriodAndNote(t *testing.T) {
+	saturday := sampleSession() // 2022-01-01
+	sunday := sampleSession()
+	sunday.Start = sunday.Start.AddDate(0, 0, 1)
+	sunday.End = sunday.End.AddDate(0, 0, 1)
+	sunday.Note = ""
+	sessions := []Session{sunday, saturday}
+
+	weeks := summarize(t, sessions, SUMMARY_GROUP_BY_WEEK, time.Sunday)
+	assert.Len(t, weeks, 2)
+	assert.Equal(t, "2021-12-26", weeks[0].Group)
+	assert.Equal(t, "2022-01-02", weeks[1].Group)
+
+	weeks = summarize(t, sessions, SUMMARY_GROUP_BY_WEEK, time.Monday)
+	assert.Len(t, weeks, 1)
+	assert.Equal(t, "2021-12-27", weeks[0].Group)
+
+	months := summarize(t, sessions, SUMMARY_GROUP_BY_MONTH, time.Sunday)
+	assert.Len(t, months, 1)
+	assert.Equal(t, "2022-01", months[0].Group)
+	assert.Equal(t, 4*time.Hour, months[0].Duration)
+
+	notes := summarize(t, sessions, SUMMARY_GROUP_BY_NOTE, time.Sunday)
+	assert.Equal(t, "<no note>", notes[0].Group)
+	assert.Equal(t, "Test Note", notes[1].Group)
+}
+
+func TestSummaryTotals_AddsUpPerCurrencyWithoutTruncating(t *testing.T) {
+	first := sampleSession()
+	first.End = first.Start.Add(1500 * time.Millisecond)
+	second := sampleSession()
+	second.Client = Client{Name: "Other", PPH: 100, Currency: "USD"}
+	second.End = second.Start.Add(1500 * time.Millisecond)
+
+	totals := SummaryTotals(summarize(t, []Session{first, second}, SUMMARY_GROUP_BY_CLIENT, time.Sunday))
+
+	assert.Len(t, totals, 1)
+	assert.Equal(t, "<all>", totals[0].Group)
+	assert.Equal(t, 3*time.Second, totals[0].Duration)
+}
+
+func TestSessionSummary_ToJSON_SetsClientOnlyWhenGroupedByClient(t *testing.T) {
+	summaries := summarize(t, []Session{sampleSession()}, SUMMARY_GROUP_BY_CLIENT, time.Sunday)
+	assert.Equal(t, "Test Client", summaries[0].ToJSON(SUMMARY_GROUP_BY_CLIENT, "").Client)
+
+	summaries = summarize(t, []Session{sampleSession()}, SUMMARY_GROUP_BY_MONTH, time.Sunday)
+	summary := summaries[0].ToJSON(SUMMARY_GROUP_BY_MONTH, "")
+	assert.Equal(t, "", summary.Client)
+	assert.Equal(t, "month", summary.GroupBy)
+	assert.Equal(t, "2022-01", summary.Group)
+}
+
+func TestSummarizeSessions_ConvertsEarningsWithRates(t *testing.T) {
+	usd := sampleSession()
+	usd.Client = Client{Name: "Acme", PPH: 100, Currency: "USD"}
+	eur := sampleSession()
+	eur.Client = Client{Name: "Euro Client", PPH: 100, Currency: "EUR"}
+	rates := ExchangeRates{Currency: "USD", Rates: []ExchangeRate{
+		{Date: time.Date(2021, time.December, 1, 0, 0, 0, 0, time.UTC), Currency: "EUR", Rate: 1.1},
+	}}
+
+	summaries, err := SummarizeSessions([]Session{usd, eur}, SUMMARY_GROUP_BY_CLIENT, time.Sunday, &rates)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 200.0, summaries[0].Converted, 0.001)
+	assert.InDelta(t, 220.0, summaries[1].Converted, 0.001)
+	total := SummaryGrandTotal(summaries, "USD")
+	assert.InDelta(t, 420.0, total.Earnings, 0.001)
+	assert.Equal(t, "USD", total.Currency)
+	assert.Equal(t, 4*time.Hour, total.Duration)
+
+	json := summaries[1].ToJSON(SUMMARY_GROUP_BY_CLIENT, "USD")
+	assert.Equal(t, 220.0, *json.ReportingEarnings)
+	assert.Equal(t, "USD", json.ReportingCurrency)
+}
+
+func TestSummarizeSessions_MissingRateFails(t *testing.T) {
+	eur := sampleSession()
+	eur.Client = Client{Name: "Euro Client", PPH: 100, Currency: "EUR"}
+	rates := ExchangeRates{Currency: "USD"}
+
+	_, err := SummarizeSessions([]Session{eur}, SUMMARY_GROUP_BY_CLIENT, time.Sunday, &rates)
+
+	assert.ErrorIs(t, err, ErrNoExchangeRate)
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Tombstone records the deletion of a session, so that syncing deletes it
+// from the remotes instead of pushing or pulling it back.
+type Tombstone struct {
+	SessionID   uint32
+	SessionUUID string
+	DeletedAt   time.Time
+}
+
+// Matches reports whether a remote session is the deleted one. Sessions are
+// matched by UUID, or by ID when either has no UUID, since IDs written by
+// other devices may collide with local ones.
+func (t Tombstone) Matches(session Session) bool {
+	if t.SessionUUID != "" && session.UUID != "" {
+		return t.SessionUUID == session.UUID
+	}
+	return session.ID != 0 && t.SessionID == session.ID
+}
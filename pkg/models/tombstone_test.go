@@ -0,0 +1,17 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTombstone_Matches(t *testing.T) {
+	tombstone := Tombstone{SessionID: 3, SessionUUID: "deleted-uuid"}
+
+	assert.True(t, tombstone.Matches(Session{UUID: "deleted-uuid"}))
+	assert.False(t, tombstone.Matches(Session{ID: 3, UUID: "another-device-uuid"}))
+	assert.True(t, tombstone.Matches(Session{ID: 3}))
+	assert.False(t, Tombstone{SessionID: 3}.Matches(Session{ID: 4, UUID: "deleted-uuid"}))
+	assert.False(t, Tombstone{}.Matches(Session{}))
+}
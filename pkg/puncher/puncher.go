@@ -3,6 +3,8 @@ package puncher
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"time"
 
@@ -14,10 +16,36 @@ var (
 	ErrSessionAlreadyStarted = errors.New("session already started")
 	ErrSessionAlreadyEnded   = errors.New("session already ended")
 	ErrInvalidSession        = errors.New("invalid session")
+	ErrSessionAlreadyPaused  = errors.New("session already paused")
+	ErrSessionNotPaused      = errors.New("session is not paused")
+	ErrProjectClientMismatch = errors.New("project does not belong to client")
+	ErrNoOpenSession         = errors.New("no open session")
+	ErrMultipleOpenSessions  = errors.New("multiple open sessions")
+	ErrOtherSessionOpen      = errors.New("another client's session is still open")
+	ErrSessionOverlaps       = errors.New("session overlaps")
+)
+
+type OverlapPolicy string
+
+const (
+	OVERLAP_POLICY_ALLOW  OverlapPolicy = "allow"
+	OVERLAP_POLICY_WARN   OverlapPolicy = "warn"
+	OVERLAP_POLICY_REJECT OverlapPolicy = "reject"
 )
 
 type Puncher struct {
 	repo repositories.SessionRepository
+
+	// ConcurrentSessions allows one open session per client instead of a
+	// single open session overall.
+	ConcurrentSessions bool
+
+	// OverlapPolicy decides what happens to sessions overlapping others,
+	// overlaps are allowed unless it is set to warn or reject.
+	OverlapPolicy OverlapPolicy
+
+	// Warn reports overlaps under the warn policy.
+	Warn func(err error)
 }
 
 func NewPuncher(repo repositories.SessionRepository) *Puncher {
@@ -26,32 +54,135 @@ func NewPuncher(repo repositories.SessionRepository) *Puncher {
 	}
 }
 
-func (p *Puncher) ToggleCheckInOut(client *models.Client, note string) (*models.Session, error) {
+// WithRepository returns a copy of the puncher that reads and stores sessions
+// through the given repository, e.g. the one of a transaction.
+func (p *Puncher) WithRepository(repo repositories.SessionRepository) *Puncher {
+	tx := *p
+	tx.repo = repo
+	return &tx
+}
+
+// ToggleCheckInOut ends the open session of the client, or starts a new one
+// if there is none. Without concurrent sessions any open session is ended.
+func (p *Puncher) ToggleCheckInOut(client *models.Client, project *models.Project, note string, tags ...string) (*models.Session, error) {
 	today := time.Now()
-	session, err := p.repo.GetLatestSession()
-	switch err {
-	case nil:
-		if session.Finished() {
-			return p.StartSession(*client, today, note)
-		} else {
-			return p.EndSession(*session, today, note)
-		}
-	case repositories.ErrSessionNotFound:
-		return p.StartSession(*client, today, note)
-	default:
+	openSessions, err := p.repo.GetOpenSessions(p.scope(client))
+	if err != nil {
+		return nil, err
+	}
+	if len(*openSessions) > 0 {
+		return p.EndSession((*openSessions)[0], today, note, tags...)
+	}
+	return p.StartSession(*client, project, today, note, tags...)
+}
+
+// OpenSessions returns the open sessions of the client, or of all clients if
+// client is nil.
+func (p *Puncher) OpenSessions(client *models.Client) ([]models.Session, error) {
+	openSessions, err := p.repo.GetOpenSessions(client)
+	if err != nil {
 		return nil, err
 	}
+	return *openSessions, nil
 }
 
-func (p *Puncher) StartSession(client models.Client, timestamp time.Time, note string) (*models.Session, error) {
-	fetchedSession, err := p.repo.GetLatestSessionOnSpecificDate(timestamp, client)
-	if err != repositories.ErrSessionNotFound && !fetchedSession.Finished() {
-		return nil, ErrSessionAlreadyStarted
+// OpenSession returns the single open session of the client, or of all
+// clients if client is nil. ErrMultipleOpenSessions is returned when the
+// session is ambiguous.
+func (p *Puncher) OpenSession(client *models.Client) (*models.Session, error) {
+	openSessions, err := p.OpenSessions(client)
+	if err != nil {
+		return nil, err
+	}
+	switch len(openSessions) {
+	case 0:
+		return nil, ErrNoOpenSession
+	case 1:
+		return &openSessions[0], nil
+	default:
+		clients := make([]string, 0, len(openSessions))
+		for _, session := range openSessions {
+			clients = append(clients, session.Client.Name)
+		}
+		return nil, fmt.Errorf("%w for %s", ErrMultipleOpenSessions, strings.Join(clients, ", "))
+	}
+}
+
+// scope is the client whose open sessions block starting a new session.
+func (p *Puncher) scope(client *models.Client) *models.Client {
+	if p.ConcurrentSessions {
+		return client
+	}
+	return nil
+}
+
+// CheckOverlaps applies the overlap policy to the session. Overlaps with
+// other clients are only checked when concurrent sessions are disabled.
+func (p *Puncher) CheckOverlaps(session models.Session) error {
+	if p.OverlapPolicy != OVERLAP_POLICY_WARN && p.OverlapPolicy != OVERLAP_POLICY_REJECT {
+		return nil
+	}
+	overlapping, err := p.repo.GetOverlappingSessions(session, p.scope(&session.Client))
+	if err != nil {
+		return err
+	}
+	if len(*overlapping) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(*overlapping))
+	for _, other := range *overlapping {
+		descriptions = append(descriptions, describeSession(other))
+	}
+	err = fmt.Errorf("%w with %s", ErrSessionOverlaps, strings.Join(descriptions, ", "))
+	if p.OverlapPolicy == OVERLAP_POLICY_WARN {
+		if p.Warn != nil {
+			p.Warn(err)
+		}
+		return nil
+	}
+	return err
+}
+
+func describeSession(session models.Session) string {
+	end := "now"
+	if session.Finished() {
+		end = session.End.Format("15:04:05")
+	}
+	return fmt.Sprintf("#%d (%s %s %s-%s)",
+		session.ID,
+		session.Client.Name,
+		session.Start.Format("2006-01-02"),
+		session.Start.Format("15:04:05"),
+		end)
+}
+
+func (p *Puncher) StartSession(client models.Client, project *models.Project, timestamp time.Time, note string, tags ...string) (*models.Session, error) {
+	openSessions, err := p.repo.GetOpenSessions(p.scope(&client))
+	if err != nil {
+		return nil, err
+	}
+	if len(*openSessions) > 0 {
+		openSession := (*openSessions)[0]
+		if openSession.Client.Name == client.Name {
+			return nil, ErrSessionAlreadyStarted
+		}
+		return nil, fmt.Errorf("%w: %s, end it or switch to %s (or enable concurrent_sessions)",
+			ErrOtherSessionOpen, openSession.Client.Name, client.Name)
+	}
+	if project != nil && project.Client.Name != client.Name {
+		return nil, ErrProjectClientMismatch
 	}
 	session := models.Session{
-		Client: client,
-		Start:  timestamp,
-		Note:   note,
+		Client:  client,
+		Project: project,
+		Start:   timestamp,
+		Note:    note,
+		Tags:    models.NormalizeTags(tags),
+	}
+	err = p.CheckOverlaps(session)
+	if err != nil {
+		return nil, err
 	}
 	err = p.repo.Insert(&session, false)
 	if err != nil {
@@ -60,7 +191,7 @@ func (p *Puncher) StartSession(client models.Client, timestamp time.Time, note s
 	return &session, nil
 }
 
-func (p *Puncher) EndSession(session models.Session, timestamp time.Time, note string) (*models.Session, error) {
+func (p *Puncher) EndSession(session models.Session, timestamp time.Time, note string, tags ...string) (*models.Session, error) {
 	if session.Finished() {
 		return nil, ErrSessionAlreadyEnded
 	}
@@ -69,6 +200,15 @@ func (p *Puncher) EndSession(session models.Session, timestamp time.Time, note s
 		return nil, ErrInvalidSession
 	}
 
+	if session.Paused() {
+		session.Breaks = slices.Clone(session.Breaks)
+		openBreak := &session.Breaks[len(session.Breaks)-1]
+		if openBreak.Start.After(timestamp) {
+			return nil, ErrInvalidSession
+		}
+		openBreak.End = timestamp
+	}
+
 	session.End = timestamp
 
 	lastyear := time.Date(time.Now().Year()-1, 1, 1, 0, 0, 0, 0, time.UTC).Year()
@@ -82,12 +222,92 @@ func (p *Puncher) EndSession(session models.Session, timestamp time.Time, note s
 		note += fmt.Sprintf("(+%d day)", int(delta))
 	}
 
-	if session.Note != "" {
+	if session.Note != "" && note != "" {
 		session.Note = session.Note + "; " + note
-	} else {
+	} else if note != "" {
 		session.Note = note
 	}
 
+	session.Tags = models.NormalizeTags(append(slices.Clone(session.Tags), tags...))
+
+	err := p.CheckOverlaps(session)
+	if err != nil {
+		return nil, err
+	}
+	err = p.repo.Update(&session, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// SwitchSession ends the open session and starts a new one for the client at
+// the same timestamp, within a single transaction.
+func (p *Puncher) SwitchSession(client models.Client, project *models.Project, timestamp time.Time, note string, tags ...string) (*models.Session, *models.Session, error) {
+	var ended, started *models.Session
+	err := p.repo.Transaction(func(repo repositories.SessionRepository) error {
+		tx := p.WithRepository(repo)
+		current, err := tx.OpenSession(nil)
+		if err != nil {
+			return err
+		}
+		ended, err = tx.EndSession(*current, timestamp, "")
+		if err != nil {
+			return err
+		}
+		started, err = tx.StartSession(client, project, timestamp, note, tags...)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return ended, started, nil
+}
+
+func (p *Puncher) PauseSession(session models.Session, timestamp time.Time) (*models.Session, error) {
+	if session.Finished() {
+		return nil, ErrSessionAlreadyEnded
+	}
+
+	if session.Paused() {
+		return nil, ErrSessionAlreadyPaused
+	}
+
+	if session.Start.After(timestamp) {
+		return nil, ErrInvalidSession
+	}
+
+	if len(session.Breaks) > 0 && session.Breaks[len(session.Breaks)-1].End.After(timestamp) {
+		return nil, ErrInvalidSession
+	}
+
+	session.Breaks = append(slices.Clone(session.Breaks), models.Break{Start: timestamp})
+
+	err := p.repo.Update(&session, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (p *Puncher) ResumeSession(session models.Session, timestamp time.Time) (*models.Session, error) {
+	if session.Finished() {
+		return nil, ErrSessionAlreadyEnded
+	}
+
+	if !session.Paused() {
+		return nil, ErrSessionNotPaused
+	}
+
+	session.Breaks = slices.Clone(session.Breaks)
+	openBreak := &session.Breaks[len(session.Breaks)-1]
+	if openBreak.Start.After(timestamp) {
+		return nil, ErrInvalidSession
+	}
+	openBreak.End = timestamp
+
 	err := p.repo.Update(&session, false)
 	if err != nil {
 		return nil, err
@@ -18,15 +18,10 @@ func TestPuncher_ToggleCheckInOut_NoRunningSessionStartsOne(t *testing.T) {
 	puncher := NewPuncher(mockRepo)
 	client := models.Client{Name: "Test"}
 
-	mockRepo.EXPECT(). // toggler looks for the latest session
-				GetLatestSession().
-				Return(nil, repositories.ErrSessionNotFound).
-				Times(1)
-
-	mockRepo.EXPECT(). // start looks for all sessions within the provided timestamp
-				GetLatestSessionOnSpecificDate(gomock.Any(), gomock.Eq(client)).
-				Return(nil, repositories.ErrSessionNotFound).
-				Times(1)
+	mockRepo.EXPECT(). // both toggler and start look for open sessions
+				GetOpenSessions(nil).
+				Return(&[]models.Session{}, nil).
+				Times(2)
 
 	mockRepo.EXPECT().
 		Insert(gomock.Any(), false).
@@ -37,7 +32,7 @@ func TestPuncher_ToggleCheckInOut_NoRunningSessionStartsOne(t *testing.T) {
 		Update(gomock.Any(), gomock.Any()).
 		Times(0)
 
-	session, err := puncher.ToggleCheckInOut(&client, "Testing Toggle")
+	session, err := puncher.ToggleCheckInOut(&client, nil, "Testing Toggle")
 
 	assert.NoError(t, err, "ToggleCheckInOut should not return an error")
 	assert.NotNil(t, session, "Session should not be nil")
@@ -61,8 +56,8 @@ func TestPuncher_ToggleCheckInOut_RunningSessionFinalizesIt(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetLatestSession().
-		Return(&runningSession, nil).
+		GetOpenSessions(nil).
+		Return(&[]models.Session{runningSession}, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
@@ -73,7 +68,7 @@ func TestPuncher_ToggleCheckInOut_RunningSessionFinalizesIt(t *testing.T) {
 		Update(gomock.Any(), gomock.Any()).
 		Times(1)
 
-	session, err := puncher.ToggleCheckInOut(&client, "Testing Toggle")
+	session, err := puncher.ToggleCheckInOut(&client, nil, "Testing Toggle")
 
 	assert.NoError(t, err, "ToggleCheckInOut should not return an error")
 	assert.NotNil(t, session, "Session should not be nil")
@@ -99,14 +94,9 @@ func TestPuncher_ToggleCheckInOut_AlreadyFinishedSessionCreatesANewOne(t *testin
 	}
 
 	mockRepo.EXPECT().
-		GetLatestSession().
-		Return(&previousSession, nil).
-		Times(1)
-
-	mockRepo.EXPECT().
-		GetLatestSessionOnSpecificDate(gomock.Any(), gomock.Eq(client)).
-		Return(&previousSession, nil).
-		Times(1)
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
+		Times(2)
 
 	mockRepo.EXPECT().
 		Insert(gomock.Any(), false).
@@ -117,7 +107,7 @@ func TestPuncher_ToggleCheckInOut_AlreadyFinishedSessionCreatesANewOne(t *testin
 		Update(gomock.Any(), gomock.Any()).
 		Times(0)
 
-	session, err := puncher.ToggleCheckInOut(&client, "Testing Toggle")
+	session, err := puncher.ToggleCheckInOut(&client, nil, "Testing Toggle")
 
 	assert.NoError(t, err, "ToggleCheckInOut should not return an error")
 	assert.NotNil(t, session, "Session should not be nil")
@@ -127,6 +117,32 @@ func TestPuncher_ToggleCheckInOut_AlreadyFinishedSessionCreatesANewOne(t *testin
 	assert.Less(t, previousSession.Start, session.Start, "Previous session start time is not less than new session start time")
 }
 
+func TestPuncher_ToggleCheckInOut_ConcurrentSessionsOnlyEndsClientSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+	puncher.ConcurrentSessions = true
+	client := models.Client{Name: "Test"}
+
+	mockRepo.EXPECT(). // another client's open session is not part of the client's scope
+				GetOpenSessions(gomock.Eq(&client)).
+				Return(&[]models.Session{}, nil).
+				Times(2)
+
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	session, err := puncher.ToggleCheckInOut(&client, nil, "")
+
+	assert.NoError(t, err, "ToggleCheckInOut should not return an error")
+	assert.False(t, session.Finished(), "Session should not be finished")
+	assert.Equal(t, client.Name, session.Client.Name, "Client name should match")
+}
+
 func TestPuncher_StartSession_NoPreviousSession(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -137,8 +153,8 @@ func TestPuncher_StartSession_NoPreviousSession(t *testing.T) {
 	now := time.Now()
 
 	mockRepo.EXPECT().
-		GetLatestSessionOnSpecificDate(gomock.Any(), gomock.Eq(client)).
-		Return(nil, repositories.ErrSessionNotFound).
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
@@ -146,7 +162,7 @@ func TestPuncher_StartSession_NoPreviousSession(t *testing.T) {
 		Return(nil).
 		Times(1)
 
-	session, err := puncher.StartSession(client, now, "")
+	session, err := puncher.StartSession(client, nil, now, "")
 
 	assert.NoError(t, err, "StartSession should not return an error")
 	assert.NotNil(t, session, "Session should not be nil")
@@ -170,11 +186,11 @@ func TestPuncher_StartSession_PreviousSessionNotEndedYet(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetLatestSessionOnSpecificDate(gomock.Any(), gomock.Eq(client)).
-		Return(&previousSession, nil).
+		GetOpenSessions(nil).
+		Return(&[]models.Session{previousSession}, nil).
 		Times(1)
 
-	session, err := puncher.StartSession(client, now, "")
+	session, err := puncher.StartSession(client, nil, now, "")
 
 	assert.Nil(t, session, "Session should be nil")
 	assert.Error(t, err, "StartSession should return an error")
@@ -199,8 +215,8 @@ func TestPuncher_StartSession_PreviousSessionInTimespaceAlreadyEndedStartsANewOn
 	}
 
 	mockRepo.EXPECT().
-		GetLatestSessionOnSpecificDate(gomock.Any(), gomock.Eq(client)).
-		Return(&previousSession, nil).
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
@@ -208,7 +224,7 @@ func TestPuncher_StartSession_PreviousSessionInTimespaceAlreadyEndedStartsANewOn
 		Return(nil).
 		Times(1)
 
-	session, err := puncher.StartSession(client, now, "")
+	session, err := puncher.StartSession(client, nil, now, "")
 
 	assert.NoError(t, err, "StartSession should not return an error")
 	assert.NotNil(t, session, "Session should not be nil")
@@ -216,6 +232,99 @@ func TestPuncher_StartSession_PreviousSessionInTimespaceAlreadyEndedStartsANewOn
 	assert.NotEqual(t, previousSession.Start, session.Start, "Previous session start time should not match new session start time")
 }
 
+func TestPuncher_StartSession_OtherClientSessionOpen(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+	client := models.Client{Name: "Test"}
+	otherSession := models.Session{
+		Client: models.Client{Name: "Other"},
+		Start:  time.Now().Add(-time.Hour),
+	}
+
+	mockRepo.EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{otherSession}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	session, err := puncher.StartSession(client, nil, time.Now(), "")
+
+	assert.Nil(t, session, "Session should be nil")
+	assert.ErrorIs(t, err, ErrOtherSessionOpen, "StartSession should return ErrOtherSessionOpen")
+	assert.ErrorContains(t, err, "switch to Test")
+}
+
+func TestPuncher_StartSession_ConcurrentAllowsOtherClient(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+	puncher.ConcurrentSessions = true
+	client := models.Client{Name: "Test"}
+
+	mockRepo.EXPECT().
+		GetOpenSessions(&client).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	session, err := puncher.StartSession(client, nil, time.Now(), "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", session.Client.Name)
+}
+
+func TestPuncher_OpenSession(t *testing.T) {
+	now := time.Now()
+	acme := models.Session{Client: models.Client{Name: "Acme"}, Start: now.Add(-time.Hour)}
+	other := models.Session{Client: models.Client{Name: "Other"}, Start: now.Add(-2 * time.Hour)}
+
+	tests := []struct {
+		name     string
+		open     []models.Session
+		expected *models.Session
+		err      error
+	}{
+		{"no open sessions", []models.Session{}, nil, ErrNoOpenSession},
+		{"single open session", []models.Session{acme}, &acme, nil},
+		{"ambiguous open sessions", []models.Session{acme, other}, nil, ErrMultipleOpenSessions},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+			puncher := NewPuncher(mockRepo)
+
+			mockRepo.EXPECT().
+				GetOpenSessions(nil).
+				Return(&test.open, nil).
+				Times(1)
+
+			session, err := puncher.OpenSession(nil)
+
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, session)
+		})
+	}
+}
+
 func TestPuncher_EndSession_FinalizedGoodSession(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -310,3 +419,484 @@ func TestPuncher_EndSession_SessionStartTimeIsAfterEndTimeDoesNothing(t *testing
 	assert.Nil(t, session, "Session should be nil")
 	assert.Equal(t, ErrInvalidSession, err, "EndSession should return ErrInvalidSession")
 }
+
+func TestPuncher_SwitchSession_EndsAndStartsAtSameTime(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+
+	now := time.Now()
+	runningSession := models.Session{
+		Client: models.Client{Name: "Test"},
+		Start:  now.Add(-time.Hour),
+		Note:   "Working",
+	}
+	nextClient := models.Client{Name: "Next"}
+
+	mockRepo.EXPECT().
+		Transaction(gomock.Any()).
+		DoAndReturn(func(fn func(repositories.SessionRepository) error) error {
+			return fn(mockRepo)
+		}).
+		Times(1)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().
+			GetOpenSessions(nil).
+			Return(&[]models.Session{runningSession}, nil),
+		mockRepo.EXPECT().
+			Update(gomock.Any(), false).
+			Return(nil),
+		mockRepo.EXPECT().
+			GetOpenSessions(nil).
+			Return(&[]models.Session{}, nil),
+		mockRepo.EXPECT().
+			Insert(gomock.Any(), false).
+			Return(nil),
+	)
+
+	ended, started, err := puncher.SwitchSession(nextClient, nil, now, "Next task")
+
+	assert.NoError(t, err, "SwitchSession should not return an error")
+	assert.Equal(t, now, ended.End, "Session should end at the switch time")
+	assert.Equal(t, "Working", ended.Note, "Ended session note should be untouched")
+	assert.Equal(t, now, started.Start, "New session should start at the switch time")
+	assert.Equal(t, nextClient.Name, started.Client.Name, "Client name should match")
+	assert.Equal(t, "Next task", started.Note, "Note should be set on the new session")
+}
+
+func TestPuncher_SwitchSession_OverlapRejected(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+	puncher.OverlapPolicy = OVERLAP_POLICY_REJECT
+
+	now := time.Now()
+	runningSession := models.Session{
+		ID:     2,
+		Client: models.Client{Name: "Test"},
+		Start:  now.Add(-30 * time.Minute),
+	}
+	previousSession := models.Session{
+		ID:     1,
+		Client: models.Client{Name: "Next"},
+		Start:  now.Add(-25 * time.Minute),
+		End:    now.Add(-10 * time.Minute),
+	}
+
+	mockRepo.EXPECT().
+		Transaction(gomock.Any()).
+		DoAndReturn(func(fn func(repositories.SessionRepository) error) error {
+			return fn(mockRepo)
+		}).
+		Times(1)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().
+			GetOpenSessions(nil).
+			Return(&[]models.Session{runningSession}, nil),
+		mockRepo.EXPECT().
+			GetOverlappingSessions(gomock.Any(), nil).
+			Return(&[]models.Session{}, nil),
+		mockRepo.EXPECT().
+			Update(gomock.Any(), false).
+			Return(nil),
+		mockRepo.EXPECT().
+			GetOpenSessions(nil).
+			Return(&[]models.Session{}, nil),
+		mockRepo.EXPECT().
+			GetOverlappingSessions(gomock.Any(), nil).
+			Return(&[]models.Session{previousSession}, nil),
+	)
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	ended, started, err := puncher.SwitchSession(previousSession.Client, nil, now.Add(-15*time.Minute), "")
+
+	assert.Nil(t, ended, "Ended session should be nil")
+	assert.Nil(t, started, "Started session should be nil")
+	assert.ErrorIs(t, err, ErrSessionOverlaps, "SwitchSession should return ErrSessionOverlaps")
+}
+
+func TestPuncher_SwitchSession_FailingStartReturnsError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+
+	now := time.Now()
+	runningSession := models.Session{
+		Client: models.Client{Name: "Test"},
+		Start:  now.Add(-time.Hour),
+	}
+	project := models.Project{Name: "Website", Client: models.Client{Name: "Other"}}
+
+	mockRepo.EXPECT().
+		Transaction(gomock.Any()).
+		DoAndReturn(func(fn func(repositories.SessionRepository) error) error {
+			return fn(mockRepo)
+		}).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{runningSession}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Update(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	ended, started, err := puncher.SwitchSession(models.Client{Name: "Next"}, &project, now, "")
+
+	assert.Equal(t, ErrProjectClientMismatch, err, "SwitchSession should return the start error for the transaction to roll back")
+	assert.Nil(t, ended, "Ended session should be nil")
+	assert.Nil(t, started, "Started session should be nil")
+}
+
+func TestPuncher_SwitchSession_NoOpenSessionDoesNothing(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+
+	mockRepo.EXPECT().
+		Transaction(gomock.Any()).
+		DoAndReturn(func(fn func(repositories.SessionRepository) error) error {
+			return fn(mockRepo)
+		}).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	_, _, err := puncher.SwitchSession(models.Client{Name: "Next"}, nil, time.Now(), "")
+
+	assert.Equal(t, ErrNoOpenSession, err, "SwitchSession should return ErrNoOpenSession")
+}
+
+func TestPuncher_PauseSession_RunningSessionAddsBreak(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+
+	client := models.Client{Name: "Test"}
+	now := time.Now()
+	runningSession := models.Session{
+		Client: client,
+		Start:  now.Add(-time.Hour),
+	}
+
+	mockRepo.EXPECT().
+		Update(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	session, err := puncher.PauseSession(runningSession, now)
+
+	assert.NoError(t, err, "PauseSession should not return an error")
+	assert.True(t, session.Paused(), "Session should be paused")
+	assert.Equal(t, now, session.Breaks[0].Start, "Break should start at the given time")
+}
+
+func TestPuncher_PauseSession_AlreadyPausedDoesNothing(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+
+	now := time.Now()
+	pausedSession := models.Session{
+		Client: models.Client{Name: "Test"},
+		Start:  now.Add(-time.Hour),
+		Breaks: []models.Break{{Start: now.Add(-time.Minute)}},
+	}
+
+	mockRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	session, err := puncher.PauseSession(pausedSession, now)
+
+	assert.Nil(t, session, "Session should be nil")
+	assert.Equal(t, ErrSessionAlreadyPaused, err, "PauseSession should return ErrSessionAlreadyPaused")
+}
+
+func TestPuncher_PauseSession_FinishedSessionDoesNothing(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+
+	now := time.Now()
+	finishedSession := models.Session{
+		Client: models.Client{Name: "Test"},
+		Start:  now.Add(-time.Hour),
+		End:    now.Add(-time.Minute),
+	}
+
+	mockRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	session, err := puncher.PauseSession(finishedSession, now)
+
+	assert.Nil(t, session, "Session should be nil")
+	assert.Equal(t, ErrSessionAlreadyEnded, err, "PauseSession should return ErrSessionAlreadyEnded")
+}
+
+func TestPuncher_ResumeSession_PausedSessionClosesBreak(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+
+	now := time.Now()
+	pausedSession := models.Session{
+		Client: models.Client{Name: "Test"},
+		Start:  now.Add(-time.Hour),
+		Breaks: []models.Break{{Start: now.Add(-30 * time.Minute)}},
+	}
+
+	mockRepo.EXPECT().
+		Update(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	session, err := puncher.ResumeSession(pausedSession, now)
+
+	assert.NoError(t, err, "ResumeSession should not return an error")
+	assert.False(t, session.Paused(), "Session should not be paused anymore")
+	assert.Equal(t, 30*time.Minute, session.BreakDuration(), "Break should last until resumed")
+	assert.False(t, pausedSession.Breaks[0].Finished(), "Original session should not be modified")
+}
+
+func TestPuncher_ResumeSession_NotPausedDoesNothing(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+
+	now := time.Now()
+	runningSession := models.Session{
+		Client: models.Client{Name: "Test"},
+		Start:  now.Add(-time.Hour),
+	}
+
+	mockRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	session, err := puncher.ResumeSession(runningSession, now)
+
+	assert.Nil(t, session, "Session should be nil")
+	assert.Equal(t, ErrSessionNotPaused, err, "ResumeSession should return ErrSessionNotPaused")
+}
+
+func TestPuncher_EndSession_PausedSessionClosesBreak(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+
+	now := time.Now()
+	pausedSession := models.Session{
+		Client: models.Client{Name: "Test"},
+		Start:  now.Add(-time.Hour),
+		Breaks: []models.Break{{Start: now.Add(-15 * time.Minute)}},
+	}
+
+	mockRepo.EXPECT().
+		Update(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	session, err := puncher.EndSession(pausedSession, now, "")
+
+	assert.NoError(t, err, "EndSession should not return an error")
+	assert.True(t, session.Breaks[0].Finished(), "Open break should be closed")
+	assert.Equal(t, 45*time.Minute, session.WorkDuration(), "Work duration should not include the break")
+}
+
+func TestPuncher_StartSession_WithTags(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+	client := models.Client{Name: "Test"}
+
+	mockRepo.EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	session, err := puncher.StartSession(client, nil, time.Now(), "", "meetings", "dev", "dev")
+
+	assert.NoError(t, err, "StartSession should not return an error")
+	assert.Equal(t, []string{"dev", "meetings"}, session.Tags, "Tags should be normalized")
+}
+
+func TestPuncher_StartSession_WithProject(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+	client := models.Client{Name: "Test"}
+	project := models.Project{Name: "Website", Client: client}
+
+	mockRepo.EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	session, err := puncher.StartSession(client, &project, time.Now(), "")
+
+	assert.NoError(t, err, "StartSession should not return an error")
+	assert.Equal(t, "Website", session.ProjectName(), "Project should be set")
+}
+
+func TestPuncher_StartSession_ProjectOfAnotherClientDoesNothing(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+	client := models.Client{Name: "Test"}
+	project := models.Project{Name: "Website", Client: models.Client{Name: "Other"}}
+
+	mockRepo.EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	session, err := puncher.StartSession(client, &project, time.Now(), "")
+
+	assert.Nil(t, session, "Session should be nil")
+	assert.Equal(t, ErrProjectClientMismatch, err, "StartSession should return ErrProjectClientMismatch")
+}
+
+func TestPuncher_StartSession_OverlapRejected(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+	puncher.OverlapPolicy = OVERLAP_POLICY_REJECT
+	client := models.Client{Name: "Test"}
+	now := time.Now()
+	previousSession := models.Session{
+		ID:     1,
+		Client: client,
+		Start:  now.Add(-3 * time.Hour),
+		End:    now.Add(-time.Hour),
+	}
+
+	mockRepo.EXPECT().
+		GetOpenSessions(nil).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetOverlappingSessions(gomock.Any(), nil).
+		Return(&[]models.Session{previousSession}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	session, err := puncher.StartSession(client, nil, now.Add(-2*time.Hour), "")
+
+	assert.Nil(t, session, "Session should be nil")
+	assert.ErrorIs(t, err, ErrSessionOverlaps, "StartSession should return ErrSessionOverlaps")
+	assert.ErrorContains(t, err, "#1 (Test", "Error should describe the overlapping session")
+}
+
+func TestPuncher_StartSession_OverlapWarned(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+	puncher := NewPuncher(mockRepo)
+	puncher.ConcurrentSessions = true
+	puncher.OverlapPolicy = OVERLAP_POLICY_WARN
+	var warning error
+	puncher.Warn = func(err error) {
+		warning = err
+	}
+	client := models.Client{Name: "Test"}
+	now := time.Now()
+	previousSession := models.Session{
+		ID:     1,
+		Client: client,
+		Start:  now.Add(-3 * time.Hour),
+		End:    now.Add(-time.Hour),
+	}
+
+	mockRepo.EXPECT().
+		GetOpenSessions(gomock.Eq(&client)).
+		Return(&[]models.Session{}, nil).
+		Times(1)
+
+	mockRepo.EXPECT(). // with concurrent sessions only the client's sessions are checked
+				GetOverlappingSessions(gomock.Any(), gomock.Eq(&client)).
+				Return(&[]models.Session{previousSession}, nil).
+				Times(1)
+
+	mockRepo.EXPECT().
+		Insert(gomock.Any(), false).
+		Return(nil).
+		Times(1)
+
+	session, err := puncher.StartSession(client, nil, now.Add(-2*time.Hour), "")
+
+	assert.NoError(t, err, "StartSession should not return an error")
+	assert.NotNil(t, session, "Session should not be nil")
+	assert.ErrorIs(t, warning, ErrSessionOverlaps, "Overlap should be warned about")
+}
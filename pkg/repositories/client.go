@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"errors"
+	"time"
 
 	"github.com/dormunis/punch/pkg/models"
 	"gorm.io/gorm"
@@ -10,9 +11,19 @@ import (
 var ErrClientNotFound = errors.New("record not found")
 
 type RepoClient struct {
-	Name     string `gorm:"primaryKey;collate:NOCASE"`
-	PPH      uint16
-	Currency string
+	Name              string `gorm:"primaryKey;collate:NOCASE"`
+	PPH               uint16
+	Currency          string
+	RoundingIncrement uint16
+	RoundingMode      string
+	RoundingMinimum   uint16
+	Rates             []RepoHourlyRate `gorm:"foreignKey:ClientName;references:Name"`
+}
+
+type RepoHourlyRate struct {
+	ClientName    string    `gorm:"primaryKey;collate:NOCASE"`
+	EffectiveFrom time.Time `gorm:"primaryKey"`
+	PPH           uint16
 }
 
 type GORMClientRepository struct {
@@ -23,9 +34,15 @@ func NewGORMClientRepository(db *gorm.DB) *GORMClientRepository {
 	return &GORMClientRepository{db}
 }
 
+func (repo *GORMClientRepository) preload() *gorm.DB {
+	return repo.db.Preload("Rates", func(db *gorm.DB) *gorm.DB {
+		return db.Order("effective_from ASC")
+	})
+}
+
 func (repo *GORMClientRepository) GetAll() ([]models.Client, error) {
 	var repoClients []RepoClient
-	err := repo.db.Find(&repoClients).Error
+	err := repo.preload().Find(&repoClients).Error
 	if err != nil {
 		return nil, err
 	}
@@ -43,12 +60,18 @@ func (repo *GORMClientRepository) Insert(client *models.Client) error {
 
 func (repo *GORMClientRepository) Delete(client *models.Client) error {
 	repoClient := ToRepoClient(*client)
-	return repo.db.Delete(repoClient).Error
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("client_name = ?", repoClient.Name).Delete(&RepoHourlyRate{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(repoClient).Error
+	})
 }
 
 func (repo *GORMClientRepository) GetByName(name string) (*models.Client, error) {
 	var repoClient RepoClient
-	err := repo.db.Where("name = ?", name).First(&repoClient).Error
+	err := repo.preload().Where("name = ?", name).First(&repoClient).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, ErrClientNotFound
@@ -61,7 +84,7 @@ func (repo *GORMClientRepository) GetByName(name string) (*models.Client, error)
 
 func (repo *GORMClientRepository) SafeGetByName(name string) (*models.Client, error) {
 	var client RepoClient
-	err := repo.db.Where("name = ?", name).First(&client).Error
+	err := repo.preload().Where("name = ?", name).First(&client).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -78,16 +101,68 @@ func (repo *GORMClientRepository) Rename(client *models.Client, newName string)
 	return repo.db.Save(repoClient).Error
 }
 
+// Update saves the client, a change in the hourly rate is recorded in the
+// client's rate history as effective from the start of the current day.
 func (repo *GORMClientRepository) Update(client *models.Client) error {
+	var existing RepoClient
+	err := repo.db.Where("name = ?", client.Name).First(&existing).Error
+	if err == nil && existing.PPH != client.PPH {
+		today := time.Now()
+		startOfDay := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+		return repo.SetRate(client, models.HourlyRate{EffectiveFrom: startOfDay, PPH: client.PPH})
+	}
 	repoClient := ToRepoClient(*client)
 	return repo.db.Save(repoClient).Error
 }
 
+// SetRate records a new hourly rate for the client, the first change keeps the
+// previous rate as the client's initial rate so historical sessions retain
+// their earnings. The client's current rate is the latest effective one.
+func (repo *GORMClientRepository) SetRate(client *models.Client, rate models.HourlyRate) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var existing RepoClient
+		err := tx.Preload("Rates").Where("name = ?", client.Name).First(&existing).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrClientNotFound
+			}
+			return err
+		}
+
+		if len(existing.Rates) == 0 {
+			initialRate := RepoHourlyRate{ClientName: existing.Name, PPH: existing.PPH}
+			err = tx.Create(&initialRate).Error
+			if err != nil {
+				return err
+			}
+			existing.Rates = append(existing.Rates, initialRate)
+		}
+
+		newRate := RepoHourlyRate{
+			ClientName:    existing.Name,
+			EffectiveFrom: rate.EffectiveFrom,
+			PPH:           rate.PPH,
+		}
+		err = tx.Save(&newRate).Error
+		if err != nil {
+			return err
+		}
+
+		client.Rates = ToDomainRates(append(existing.Rates, newRate))
+		client.PPH = client.RateAt(time.Now())
+		repoClient := ToRepoClient(*client)
+		return tx.Save(repoClient).Error
+	})
+}
+
 func ToRepoClient(client models.Client) *RepoClient {
 	return &RepoClient{
-		Name:     client.Name,
-		PPH:      client.PPH,
-		Currency: client.Currency,
+		Name:              client.Name,
+		PPH:               client.PPH,
+		Currency:          client.Currency,
+		RoundingIncrement: client.Rounding.Increment,
+		RoundingMode:      string(client.Rounding.Mode),
+		RoundingMinimum:   client.Rounding.Minimum,
 	}
 }
 
@@ -96,5 +171,26 @@ func ToDomainClient(client RepoClient) models.Client {
 		Name:     client.Name,
 		PPH:      client.PPH,
 		Currency: client.Currency,
+		Rounding: models.Rounding{
+			Increment: client.RoundingIncrement,
+			Mode:      models.RoundingMode(client.RoundingMode),
+			Minimum:   client.RoundingMinimum,
+		},
+		Rates: ToDomainRates(client.Rates),
+	}
+}
+
+func ToDomainRates(repoRates []RepoHourlyRate) []models.HourlyRate {
+	var rates []models.HourlyRate
+	for _, repoRate := range repoRates {
+		var effectiveFrom time.Time
+		if !repoRate.EffectiveFrom.IsZero() {
+			effectiveFrom = repoRate.EffectiveFrom.In(time.Local)
+		}
+		rates = append(rates, models.HourlyRate{
+			EffectiveFrom: effectiveFrom,
+			PPH:           repoRate.PPH,
+		})
 	}
+	return rates
 }
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"gorm.io/gorm"
+)
+
+type RepoExchangeRate struct {
+	Date     time.Time `gorm:"primaryKey"`
+	Currency string    `gorm:"primaryKey;collate:NOCASE"`
+	Rate     float64
+}
+
+type GORMExchangeRateRepository struct {
+	db *gorm.DB
+}
+
+func NewGORMExchangeRateRepository(db *gorm.DB) *GORMExchangeRateRepository {
+	return &GORMExchangeRateRepository{db}
+}
+
+func (repo *GORMExchangeRateRepository) GetAll() ([]models.ExchangeRate, error) {
+	var repoRates []RepoExchangeRate
+	err := repo.db.Order("currency, date").Find(&repoRates).Error
+	if err != nil {
+		return nil, err
+	}
+	var rates []models.ExchangeRate
+	for _, repoRate := range repoRates {
+		rates = append(rates, ToDomainExchangeRate(repoRate))
+	}
+	return rates, nil
+}
+
+// Upsert stores the rates, replacing the rates of the same currency and date.
+func (repo *GORMExchangeRateRepository) Upsert(rates []models.ExchangeRate) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		for _, rate := range rates {
+			repoRate := ToRepoExchangeRate(rate)
+			err := tx.Save(&repoRate).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReplaceAll replaces the whole exchange rate table with the given rates.
+func (repo *GORMExchangeRateRepository) ReplaceAll(rates []models.ExchangeRate) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&RepoExchangeRate{}).Error
+		if err != nil {
+			return err
+		}
+		for _, rate := range rates {
+			repoRate := ToRepoExchangeRate(rate)
+			err = tx.Save(&repoRate).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func ToRepoExchangeRate(rate models.ExchangeRate) RepoExchangeRate {
+	return RepoExchangeRate{
+		Date:     time.Date(rate.Date.Year(), rate.Date.Month(), rate.Date.Day(), 0, 0, 0, 0, time.UTC),
+		Currency: rate.Currency,
+		Rate:     rate.Rate,
+	}
+}
+
+func ToDomainExchangeRate(repoRate RepoExchangeRate) models.ExchangeRate {
+	date := repoRate.Date.UTC()
+	return models.ExchangeRate{
+		Date:     time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local),
+		Currency: repoRate.Currency,
+		Rate:     repoRate.Rate,
+	}
+}
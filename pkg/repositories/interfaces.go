@@ -10,14 +10,23 @@ type SessionRepository interface {
 	Upsert(session *models.Session, dryRun bool) error
 	Update(session *models.Session, dryRun bool) error
 	Delete(session *models.Session, dryRun bool) error
+	ForceUpdate(session *models.Session, dryRun bool) error
+	ForceDelete(session *models.Session, dryRun bool) error
+	SetStatus(session *models.Session, status models.SessionStatus) error
 	GetSessionByID(id uint32) (*models.Session, error)
 	GetAllSessions(client models.Client) (*[]models.Session, error)
 	GetAllSessionsBetweenDates(start time.Time, end time.Time) (*[]models.Session, error)
+	GetAllSessionsStartedBetween(start time.Time, end time.Time) (*[]models.Session, error)
 	GetAllSessionsAllClients() (*[]models.Session, error)
 	GetLatestSession() (*models.Session, error)
 	GetLatestSessionOnSpecificDate(date time.Time, client models.Client) (*models.Session, error)
 	GetLatestSessionOnSpecificDateAllClients(date time.Time) (*[]models.Session, error)
 	GetLastSessions(uint32, *models.Client) (*[]models.Session, error)
+	GetOpenSessions(client *models.Client) (*[]models.Session, error)
+	GetOverlappingSessions(session models.Session, client *models.Client) (*[]models.Session, error)
+	GetTombstones() ([]models.Tombstone, error)
+	DeleteTombstones(sessionIDs []uint32) error
+	Transaction(fn func(repo SessionRepository) error) error
 }
 
 type ClientRepository interface {
@@ -28,4 +37,31 @@ type ClientRepository interface {
 	SafeGetByName(name string) (*models.Client, error)
 	Rename(client *models.Client, newName string) error
 	Update(client *models.Client) error
+	SetRate(client *models.Client, rate models.HourlyRate) error
+}
+
+type ProjectRepository interface {
+	GetAll() ([]models.Project, error)
+	GetAllByClient(client models.Client) ([]models.Project, error)
+	GetByName(client models.Client, name string) (*models.Project, error)
+	Insert(project *models.Project) error
+	Update(project *models.Project) error
+	Delete(project *models.Project) error
+}
+
+type InvoiceRepository interface {
+	Insert(invoice *models.Invoice) error
+	GetAll() ([]models.Invoice, error)
+}
+
+type ExchangeRateRepository interface {
+	GetAll() ([]models.ExchangeRate, error)
+	Upsert(rates []models.ExchangeRate) error
+	ReplaceAll(rates []models.ExchangeRate) error
+}
+
+type SyncSnapshotRepository interface {
+	GetAll(remote string) ([]models.Session, error)
+	Save(remote string, sessions []models.Session) error
+	Delete(remote string, sessionIDs []uint32) error
 }
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"gorm.io/gorm"
+)
+
+type RepoInvoice struct {
+	Number     uint32 `gorm:"primaryKey;autoIncrement"`
+	ClientName string `gorm:"collate:NOCASE"`
+	IssuedAt   time.Time
+	From       time.Time
+	To         time.Time
+	Client     RepoClient `gorm:"foreignKey:ClientName;references:Name"`
+}
+
+type GORMInvoiceRepository struct {
+	db *gorm.DB
+}
+
+func NewGORMInvoiceRepository(db *gorm.DB) *GORMInvoiceRepository {
+	return &GORMInvoiceRepository{db}
+}
+
+// Insert stores the invoice and assigns it the next invoice number.
+func (repo *GORMInvoiceRepository) Insert(invoice *models.Invoice) error {
+	repoInvoice := ToRepoInvoice(*invoice)
+	repoInvoice.Number = 0
+	err := repo.db.Omit("Client").Create(&repoInvoice).Error
+	if err != nil {
+		return err
+	}
+	invoice.Number = repoInvoice.Number
+	return nil
+}
+
+func (repo *GORMInvoiceRepository) GetAll() ([]models.Invoice, error) {
+	var repoInvoices []RepoInvoice
+	err := repo.db.Preload("Client").
+		Order("number").
+		Find(&repoInvoices).Error
+	if err != nil {
+		return nil, err
+	}
+	var invoices []models.Invoice
+	for _, repoInvoice := range repoInvoices {
+		invoices = append(invoices, ToDomainInvoice(repoInvoice))
+	}
+	return invoices, nil
+}
+
+func ToRepoInvoice(invoice models.Invoice) RepoInvoice {
+	return RepoInvoice{
+		Number:     invoice.Number,
+		ClientName: invoice.Client.Name,
+		IssuedAt:   invoice.IssuedAt.Truncate(time.Second),
+		From:       invoice.From,
+		To:         invoice.To,
+	}
+}
+
+func ToDomainInvoice(repoInvoice RepoInvoice) models.Invoice {
+	return models.Invoice{
+		Number:   repoInvoice.Number,
+		Client:   ToDomainClient(repoInvoice.Client),
+		IssuedAt: repoInvoice.IssuedAt.In(time.Local),
+		From:     repoInvoice.From.In(time.Local),
+		To:       repoInvoice.To.In(time.Local),
+	}
+}
@@ -49,6 +49,48 @@ func (mr *MockSessionRepositoryMockRecorder) Delete(session, dryRun any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessionRepository)(nil).Delete), session, dryRun)
 }
 
+// DeleteTombstones mocks base method.
+func (m *MockSessionRepository) DeleteTombstones(sessionIDs []uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTombstones", sessionIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTombstones indicates an expected call of DeleteTombstones.
+func (mr *MockSessionRepositoryMockRecorder) DeleteTombstones(sessionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTombstones", reflect.TypeOf((*MockSessionRepository)(nil).DeleteTombstones), sessionIDs)
+}
+
+// ForceDelete mocks base method.
+func (m *MockSessionRepository) ForceDelete(session *models.Session, dryRun bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceDelete", session, dryRun)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceDelete indicates an expected call of ForceDelete.
+func (mr *MockSessionRepositoryMockRecorder) ForceDelete(session, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceDelete", reflect.TypeOf((*MockSessionRepository)(nil).ForceDelete), session, dryRun)
+}
+
+// ForceUpdate mocks base method.
+func (m *MockSessionRepository) ForceUpdate(session *models.Session, dryRun bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceUpdate", session, dryRun)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceUpdate indicates an expected call of ForceUpdate.
+func (mr *MockSessionRepositoryMockRecorder) ForceUpdate(session, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceUpdate", reflect.TypeOf((*MockSessionRepository)(nil).ForceUpdate), session, dryRun)
+}
+
 // GetAllSessions mocks base method.
 func (m *MockSessionRepository) GetAllSessions(client models.Client) (*[]models.Session, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +136,21 @@ func (mr *MockSessionRepositoryMockRecorder) GetAllSessionsBetweenDates(start, e
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSessionsBetweenDates", reflect.TypeOf((*MockSessionRepository)(nil).GetAllSessionsBetweenDates), start, end)
 }
 
+// GetAllSessionsStartedBetween mocks base method.
+func (m *MockSessionRepository) GetAllSessionsStartedBetween(start, end time.Time) (*[]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSessionsStartedBetween", start, end)
+	ret0, _ := ret[0].(*[]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSessionsStartedBetween indicates an expected call of GetAllSessionsStartedBetween.
+func (mr *MockSessionRepositoryMockRecorder) GetAllSessionsStartedBetween(start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSessionsStartedBetween", reflect.TypeOf((*MockSessionRepository)(nil).GetAllSessionsStartedBetween), start, end)
+}
+
 // GetLastSessions mocks base method.
 func (m *MockSessionRepository) GetLastSessions(arg0 uint32, arg1 *models.Client) (*[]models.Session, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +211,36 @@ func (mr *MockSessionRepositoryMockRecorder) GetLatestSessionOnSpecificDateAllCl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSessionOnSpecificDateAllClients", reflect.TypeOf((*MockSessionRepository)(nil).GetLatestSessionOnSpecificDateAllClients), date)
 }
 
+// GetOpenSessions mocks base method.
+func (m *MockSessionRepository) GetOpenSessions(client *models.Client) (*[]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenSessions", client)
+	ret0, _ := ret[0].(*[]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenSessions indicates an expected call of GetOpenSessions.
+func (mr *MockSessionRepositoryMockRecorder) GetOpenSessions(client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenSessions", reflect.TypeOf((*MockSessionRepository)(nil).GetOpenSessions), client)
+}
+
+// GetOverlappingSessions mocks base method.
+func (m *MockSessionRepository) GetOverlappingSessions(session models.Session, client *models.Client) (*[]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverlappingSessions", session, client)
+	ret0, _ := ret[0].(*[]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverlappingSessions indicates an expected call of GetOverlappingSessions.
+func (mr *MockSessionRepositoryMockRecorder) GetOverlappingSessions(session, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverlappingSessions", reflect.TypeOf((*MockSessionRepository)(nil).GetOverlappingSessions), session, client)
+}
+
 // GetSessionByID mocks base method.
 func (m *MockSessionRepository) GetSessionByID(id uint32) (*models.Session, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +256,21 @@ func (mr *MockSessionRepositoryMockRecorder) GetSessionByID(id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByID", reflect.TypeOf((*MockSessionRepository)(nil).GetSessionByID), id)
 }
 
+// GetTombstones mocks base method.
+func (m *MockSessionRepository) GetTombstones() ([]models.Tombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTombstones")
+	ret0, _ := ret[0].([]models.Tombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTombstones indicates an expected call of GetTombstones.
+func (mr *MockSessionRepositoryMockRecorder) GetTombstones() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTombstones", reflect.TypeOf((*MockSessionRepository)(nil).GetTombstones))
+}
+
 // Insert mocks base method.
 func (m *MockSessionRepository) Insert(session *models.Session, dryRun bool) error {
 	m.ctrl.T.Helper()
@@ -183,6 +285,34 @@ func (mr *MockSessionRepositoryMockRecorder) Insert(session, dryRun any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockSessionRepository)(nil).Insert), session, dryRun)
 }
 
+// SetStatus mocks base method.
+func (m *MockSessionRepository) SetStatus(session *models.Session, status models.SessionStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", session, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockSessionRepositoryMockRecorder) SetStatus(session, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockSessionRepository)(nil).SetStatus), session, status)
+}
+
+// Transaction mocks base method.
+func (m *MockSessionRepository) Transaction(fn func(SessionRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockSessionRepositoryMockRecorder) Transaction(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockSessionRepository)(nil).Transaction), fn)
+}
+
 // Update mocks base method.
 func (m *MockSessionRepository) Update(session *models.Session, dryRun bool) error {
 	m.ctrl.T.Helper()
@@ -321,6 +451,20 @@ func (mr *MockClientRepositoryMockRecorder) SafeGetByName(name any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SafeGetByName", reflect.TypeOf((*MockClientRepository)(nil).SafeGetByName), name)
 }
 
+// SetRate mocks base method.
+func (m *MockClientRepository) SetRate(client *models.Client, rate models.HourlyRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRate", client, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRate indicates an expected call of SetRate.
+func (mr *MockClientRepositoryMockRecorder) SetRate(client, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRate", reflect.TypeOf((*MockClientRepository)(nil).SetRate), client, rate)
+}
+
 // Update mocks base method.
 func (m *MockClientRepository) Update(client *models.Client) error {
 	m.ctrl.T.Helper()
@@ -334,3 +478,297 @@ func (mr *MockClientRepositoryMockRecorder) Update(client any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClientRepository)(nil).Update), client)
 }
+
+// MockProjectRepository is a mock of ProjectRepository interface.
+type MockProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectRepositoryMockRecorder
+}
+
+// MockProjectRepositoryMockRecorder is the mock recorder for MockProjectRepository.
+type MockProjectRepositoryMockRecorder struct {
+	mock *MockProjectRepository
+}
+
+// NewMockProjectRepository creates a new mock instance.
+func NewMockProjectRepository(ctrl *gomock.Controller) *MockProjectRepository {
+	mock := &MockProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectRepository) EXPECT() *MockProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProjectRepository) Delete(project *models.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProjectRepositoryMockRecorder) Delete(project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProjectRepository)(nil).Delete), project)
+}
+
+// GetAll mocks base method.
+func (m *MockProjectRepository) GetAll() ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockProjectRepositoryMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProjectRepository)(nil).GetAll))
+}
+
+// GetAllByClient mocks base method.
+func (m *MockProjectRepository) GetAllByClient(client models.Client) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByClient", client)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByClient indicates an expected call of GetAllByClient.
+func (mr *MockProjectRepositoryMockRecorder) GetAllByClient(client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByClient", reflect.TypeOf((*MockProjectRepository)(nil).GetAllByClient), client)
+}
+
+// GetByName mocks base method.
+func (m *MockProjectRepository) GetByName(client models.Client, name string) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", client, name)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockProjectRepositoryMockRecorder) GetByName(client, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockProjectRepository)(nil).GetByName), client, name)
+}
+
+// Insert mocks base method.
+func (m *MockProjectRepository) Insert(project *models.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockProjectRepositoryMockRecorder) Insert(project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockProjectRepository)(nil).Insert), project)
+}
+
+// Update mocks base method.
+func (m *MockProjectRepository) Update(project *models.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProjectRepositoryMockRecorder) Update(project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProjectRepository)(nil).Update), project)
+}
+
+// MockInvoiceRepository is a mock of InvoiceRepository interface.
+type MockInvoiceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceRepositoryMockRecorder
+}
+
+// MockInvoiceRepositoryMockRecorder is the mock recorder for MockInvoiceRepository.
+type MockInvoiceRepositoryMockRecorder struct {
+	mock *MockInvoiceRepository
+}
+
+// NewMockInvoiceRepository creates a new mock instance.
+func NewMockInvoiceRepository(ctrl *gomock.Controller) *MockInvoiceRepository {
+	mock := &MockInvoiceRepository{ctrl: ctrl}
+	mock.recorder = &MockInvoiceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceRepository) EXPECT() *MockInvoiceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockInvoiceRepository) GetAll() ([]models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockInvoiceRepositoryMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockInvoiceRepository)(nil).GetAll))
+}
+
+// Insert mocks base method.
+func (m *MockInvoiceRepository) Insert(invoice *models.Invoice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", invoice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockInvoiceRepositoryMockRecorder) Insert(invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockInvoiceRepository)(nil).Insert), invoice)
+}
+
+// MockExchangeRateRepository is a mock of ExchangeRateRepository interface.
+type MockExchangeRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateRepositoryMockRecorder
+}
+
+// MockExchangeRateRepositoryMockRecorder is the mock recorder for MockExchangeRateRepository.
+type MockExchangeRateRepositoryMockRecorder struct {
+	mock *MockExchangeRateRepository
+}
+
+// NewMockExchangeRateRepository creates a new mock instance.
+func NewMockExchangeRateRepository(ctrl *gomock.Controller) *MockExchangeRateRepository {
+	mock := &MockExchangeRateRepository{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateRepository) EXPECT() *MockExchangeRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockExchangeRateRepository) GetAll() ([]models.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockExchangeRateRepositoryMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockExchangeRateRepository)(nil).GetAll))
+}
+
+// ReplaceAll mocks base method.
+func (m *MockExchangeRateRepository) ReplaceAll(rates []models.ExchangeRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAll", rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceAll indicates an expected call of ReplaceAll.
+func (mr *MockExchangeRateRepositoryMockRecorder) ReplaceAll(rates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAll", reflect.TypeOf((*MockExchangeRateRepository)(nil).ReplaceAll), rates)
+}
+
+// Upsert mocks base method.
+func (m *MockExchangeRateRepository) Upsert(rates []models.ExchangeRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockExchangeRateRepositoryMockRecorder) Upsert(rates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockExchangeRateRepository)(nil).Upsert), rates)
+}
+
+// MockSyncSnapshotRepository is a mock of SyncSnapshotRepository interface.
+type MockSyncSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncSnapshotRepositoryMockRecorder
+}
+
+// MockSyncSnapshotRepositoryMockRecorder is the mock recorder for MockSyncSnapshotRepository.
+type MockSyncSnapshotRepositoryMockRecorder struct {
+	mock *MockSyncSnapshotRepository
+}
+
+// NewMockSyncSnapshotRepository creates a new mock instance.
+func NewMockSyncSnapshotRepository(ctrl *gomock.Controller) *MockSyncSnapshotRepository {
+	mock := &MockSyncSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockSyncSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncSnapshotRepository) EXPECT() *MockSyncSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSyncSnapshotRepository) Delete(remote string, sessionIDs []uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", remote, sessionIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSyncSnapshotRepositoryMockRecorder) Delete(remote, sessionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSyncSnapshotRepository)(nil).Delete), remote, sessionIDs)
+}
+
+// GetAll mocks base method.
+func (m *MockSyncSnapshotRepository) GetAll(remote string) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", remote)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockSyncSnapshotRepositoryMockRecorder) GetAll(remote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSyncSnapshotRepository)(nil).GetAll), remote)
+}
+
+// Save mocks base method.
+func (m *MockSyncSnapshotRepository) Save(remote string, sessions []models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", remote, sessions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSyncSnapshotRepositoryMockRecorder) Save(remote, sessions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSyncSnapshotRepository)(nil).Save), remote, sessions)
+}
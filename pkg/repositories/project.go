@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/dormunis/punch/pkg/models"
+	"gorm.io/gorm"
+)
+
+var ErrProjectNotFound = errors.New("project not found")
+
+type RepoProject struct {
+	Name       string `gorm:"primaryKey;collate:NOCASE"`
+	ClientName string `gorm:"primaryKey;collate:NOCASE"`
+	PPH        *uint16
+	Client     RepoClient `gorm:"foreignKey:ClientName;references:Name"`
+}
+
+type GORMProjectRepository struct {
+	db *gorm.DB
+}
+
+func NewGORMProjectRepository(db *gorm.DB) *GORMProjectRepository {
+	return &GORMProjectRepository{db}
+}
+
+func (repo *GORMProjectRepository) GetAll() ([]models.Project, error) {
+	var repoProjects []RepoProject
+	err := repo.db.Preload("Client.Rates").
+		Order("client_name, name").
+		Find(&repoProjects).Error
+	if err != nil {
+		return nil, err
+	}
+	var projects []models.Project
+	for _, repoProject := range repoProjects {
+		projects = append(projects, ToDomainProject(repoProject))
+	}
+	return projects, nil
+}
+
+func (repo *GORMProjectRepository) GetAllByClient(client models.Client) ([]models.Project, error) {
+	var repoProjects []RepoProject
+	err := repo.db.Preload("Client.Rates").
+		Where("client_name = ?", client.Name).
+		Order("name").
+		Find(&repoProjects).Error
+	if err != nil {
+		return nil, err
+	}
+	var projects []models.Project
+	for _, repoProject := range repoProjects {
+		projects = append(projects, ToDomainProject(repoProject))
+	}
+	return projects, nil
+}
+
+func (repo *GORMProjectRepository) GetByName(client models.Client, name string) (*models.Project, error) {
+	var repoProject RepoProject
+	err := repo.db.Preload("Client.Rates").
+		Where("client_name = ? AND name = ?", client.Name, name).
+		First(&repoProject).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	project := ToDomainProject(repoProject)
+	return &project, nil
+}
+
+func (repo *GORMProjectRepository) Insert(project *models.Project) error {
+	repoProject := ToRepoProject(*project)
+	return repo.db.Omit("Client").Create(repoProject).Error
+}
+
+func (repo *GORMProjectRepository) Update(project *models.Project) error {
+	repoProject := ToRepoProject(*project)
+	return repo.db.Omit("Client").Save(repoProject).Error
+}
+
+func (repo *GORMProjectRepository) Delete(project *models.Project) error {
+	repoProject := ToRepoProject(*project)
+	return repo.db.Omit("Client").Delete(repoProject).Error
+}
+
+func ToRepoProject(project models.Project) *RepoProject {
+	return &RepoProject{
+		Name:       project.Name,
+		ClientName: project.Client.Name,
+		PPH:        project.PPH,
+		Client:     *ToRepoClient(project.Client),
+	}
+}
+
+func ToDomainProject(project RepoProject) models.Project {
+	return models.Project{
+		Name:   project.Name,
+		Client: ToDomainClient(project.Client),
+		PPH:    project.PPH,
+	}
+}
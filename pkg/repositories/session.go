@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/dormunis/punch/pkg/models"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -12,15 +13,70 @@ var (
 	ErrSessionNotFound = errors.New("session record not found")
 	ErrConflictingIds  = errors.New("session already exists with a different ID")
 	ErrInfoConflict    = errors.New("session exists with different info")
+	ErrSessionLocked   = errors.New("session is invoiced or paid and cannot be modified")
 )
 
+// RepoSession is a stored session. Its UUID is only written when the session
+// is created, so that it never changes once it was synced.
 type RepoSession struct {
-	ID         uint32 `gorm:"primaryKey;autoIncrement"`
-	ClientName string `gorm:"foreignKey:Name"`
-	Start      time.Time
-	End        time.Time
-	Note       string
-	Client     RepoClient `gorm:"foreignKey:ClientName;references:Name"`
+	ID            uint32 `gorm:"primaryKey;autoIncrement"`
+	UUID          string `gorm:"uniqueIndex;<-:create"`
+	ClientName    string `gorm:"foreignKey:Name"`
+	ProjectName   string
+	Start         time.Time
+	End           time.Time
+	Note          string
+	InvoiceNumber uint32 `gorm:"index"`
+	Status        string
+	Client        RepoClient  `gorm:"foreignKey:ClientName;references:Name"`
+	Project       RepoProject `gorm:"foreignKey:ProjectName,ClientName;references:Name,ClientName"`
+	Breaks        []RepoBreak `gorm:"foreignKey:SessionID"`
+	Tags          []RepoTag   `gorm:"many2many:session_tags"`
+}
+
+// RepoTombstone is left behind by a deleted session.
+type RepoTombstone struct {
+	SessionID   uint32 `gorm:"primaryKey;autoIncrement:false"`
+	SessionUUID string `gorm:"index"`
+	DeletedAt   time.Time
+}
+
+type RepoBreak struct {
+	ID        uint32 `gorm:"primaryKey;autoIncrement"`
+	SessionID uint32 `gorm:"index"`
+	Start     time.Time
+	End       time.Time
+}
+
+// BeforeCreate assigns a UUID to sessions that were not synced from another
+// device.
+func (s *RepoSession) BeforeCreate(tx *gorm.DB) error {
+	if s.UUID == "" {
+		s.UUID = uuid.NewString()
+	}
+	return nil
+}
+
+// BackfillSessionUUIDs assigns a UUID to the sessions created before sessions
+// had one.
+func BackfillSessionUUIDs(db *gorm.DB) error {
+	var ids []uint32
+	err := db.Model(&RepoSession{}).
+		Where("uuid IS NULL OR uuid = ''").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			// the UUID column is create only, so it is updated directly
+			err := tx.Exec("UPDATE repo_sessions SET uuid = ? WHERE id = ?", uuid.NewString(), id).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 type GORMSessionRepository struct {
@@ -31,6 +87,24 @@ func NewGORMSessionRepository(db *gorm.DB) *GORMSessionRepository {
 	return &GORMSessionRepository{db}
 }
 
+// Transaction runs fn with a repository bound to a single database
+// transaction, which is rolled back if fn returns an error.
+func (repo *GORMSessionRepository) Transaction(fn func(repo SessionRepository) error) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewGORMSessionRepository(tx))
+	})
+}
+
+func (repo *GORMSessionRepository) preload() *gorm.DB {
+	return repo.db.Preload("Client").
+		Preload("Client.Rates").
+		Preload("Project").
+		Preload("Breaks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start ASC")
+		}).
+		Preload("Tags")
+}
+
 func (repo *GORMSessionRepository) Insert(session *models.Session, dryRun bool) error {
 	repoSession := ToRepoSession(*session)
 
@@ -58,12 +132,24 @@ func (repo *GORMSessionRepository) Insert(session *models.Session, dryRun bool)
 	}
 
 	if dryRun {
-		return repo.db.Session(&gorm.Session{DryRun: true}).Create(&repoSession).Error
+		return repo.db.Session(&gorm.Session{DryRun: true}).Omit("Project").Create(&repoSession).Error
 	}
-	return repo.db.Create(&repoSession).Error
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("Project").Create(&repoSession).Error
+		if err != nil {
+			return err
+		}
+		// a session inserted again with the ID or UUID of a deleted one is alive
+		return tx.Where("session_id = ? OR session_uuid = ?", repoSession.ID, repoSession.UUID).
+			Delete(&RepoTombstone{}).Error
+	})
 }
 
 func (repo *GORMSessionRepository) Upsert(session *models.Session, dryRun bool) error {
+	err := repo.checkLocked(session.ID)
+	if err != nil {
+		return err
+	}
 	repoSession := ToRepoSession(*session)
 	if dryRun {
 		return repo.db.Session(&gorm.Session{DryRun: true}).Save(&repoSession).Error
@@ -78,12 +164,13 @@ func (repo *GORMSessionRepository) Upsert(session *models.Session, dryRun bool)
 		session.ID = existingByDetails.ID
 	}
 
-	return repo.db.Save(&repoSession).Error
+	// remotes are not aware of breaks, tags and invoices, so they are left untouched
+	return repo.db.Omit("Project", "Breaks", "Tags", "InvoiceNumber", "Status").Save(&repoSession).Error
 }
 
 func (repo *GORMSessionRepository) GetSessionByID(id uint32) (*models.Session, error) {
 	var repoSession RepoSession
-	err := repo.db.Preload("Client").Where("id = ?", id).First(&repoSession).Error
+	err := repo.preload().Where("id = ?", id).First(&repoSession).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, ErrSessionNotFound
@@ -96,7 +183,7 @@ func (repo *GORMSessionRepository) GetSessionByID(id uint32) (*models.Session, e
 
 func (repo *GORMSessionRepository) GetLatestSession() (*models.Session, error) {
 	var session RepoSession
-	err := repo.db.Preload("Client").
+	err := repo.preload().
 		Order("start DESC").
 		First(&session).Error
 	if err != nil {
@@ -109,12 +196,62 @@ func (repo *GORMSessionRepository) GetLatestSession() (*models.Session, error) {
 	return &domainSession, nil
 }
 
+// GetOpenSessions returns the sessions that were started but not ended yet,
+// latest first. A nil client returns the open sessions of all clients.
+func (repo *GORMSessionRepository) GetOpenSessions(client *models.Client) (*[]models.Session, error) {
+	var repoSessions []RepoSession
+	query := repo.preload().
+		Where(repo.db.Where("end IS NULL").
+			Or("end IS ''").
+			Or("end = ?", models.NULL_TIME))
+	if client != nil {
+		query = query.Where("client_name = ?", client.Name)
+	}
+	err := query.Order("start DESC").Find(&repoSessions).Error
+	if err != nil {
+		return nil, err
+	}
+	sessions := []models.Session{}
+	for _, repoSession := range repoSessions {
+		sessions = append(sessions, ToDomainSession(repoSession))
+	}
+	return &sessions, nil
+}
+
+// GetOverlappingSessions returns the other sessions that were running at the
+// same time as the given session. A nil client checks the sessions of all
+// clients.
+func (repo *GORMSessionRepository) GetOverlappingSessions(session models.Session, client *models.Client) (*[]models.Session, error) {
+	var repoSessions []RepoSession
+	query := repo.preload().
+		Where("id <> ?", session.ID).
+		Where(repo.db.Where("end > ?", session.Start.Truncate(time.Second)).
+			Or("end IS NULL").
+			Or("end IS ''").
+			Or("end = ?", models.NULL_TIME))
+	if session.Finished() {
+		query = query.Where("start < ?", session.End.Truncate(time.Second))
+	}
+	if client != nil {
+		query = query.Where("client_name = ?", client.Name)
+	}
+	err := query.Order("start ASC").Find(&repoSessions).Error
+	if err != nil {
+		return nil, err
+	}
+	sessions := []models.Session{}
+	for _, repoSession := range repoSessions {
+		sessions = append(sessions, ToDomainSession(repoSession))
+	}
+	return &sessions, nil
+}
+
 func (repo *GORMSessionRepository) GetLatestSessionOnSpecificDateAllClients(date time.Time) (*[]models.Session, error) {
 	var repoSessions []RepoSession
 	startOfDay := date.Truncate(24 * time.Hour)
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
-	err := repo.db.Preload("Client").
+	err := repo.preload().
 		Where("start >= ? AND start < ?",
 			startOfDay,
 			endOfDay).
@@ -138,7 +275,7 @@ func (repo *GORMSessionRepository) GetLatestSessionOnSpecificDate(date time.Time
 	startOfDay := date.Truncate(24 * time.Hour)
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
-	err := repo.db.Preload("Client").
+	err := repo.preload().
 		Where("start >= ? AND start < ? AND client_name = ?",
 			startOfDay,
 			endOfDay,
@@ -155,25 +292,166 @@ func (repo *GORMSessionRepository) GetLatestSessionOnSpecificDate(date time.Time
 	return &domainSession, nil
 }
 
+// checkLocked returns ErrSessionLocked if the stored session was invoiced or
+// paid, sessions that are not stored yet are never locked.
+func (repo *GORMSessionRepository) checkLocked(id uint32) error {
+	if id == 0 {
+		return nil
+	}
+	var existing RepoSession
+	err := repo.db.Select("status").Where("id = ?", id).First(&existing).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+	if models.SessionStatus(existing.Status).Locked() {
+		return ErrSessionLocked
+	}
+	return nil
+}
+
+// Update saves the session, invoiced and paid sessions are refused with
+// ErrSessionLocked.
 func (repo *GORMSessionRepository) Update(session *models.Session, dryRun bool) error {
+	err := repo.checkLocked(session.ID)
+	if err != nil {
+		return err
+	}
+	return repo.update(session, dryRun)
+}
+
+// ForceUpdate saves the session regardless of its status.
+func (repo *GORMSessionRepository) ForceUpdate(session *models.Session, dryRun bool) error {
+	return repo.update(session, dryRun)
+}
+
+func (repo *GORMSessionRepository) update(session *models.Session, dryRun bool) error {
 	repoSession := ToRepoSession(*session)
 	if dryRun {
-		return repo.db.Session(&gorm.Session{DryRun: true}).Save(&repoSession).Error
+		return repo.db.Session(&gorm.Session{DryRun: true}).Omit("Project", "Breaks", "Tags").Save(&repoSession).Error
 	}
-	return repo.db.Save(&repoSession).Error
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("Project", "Breaks", "Tags").Save(&repoSession).Error
+		if err != nil {
+			return err
+		}
+		err = replaceBreaks(tx, &repoSession)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&repoSession).Association("Tags").Replace(repoSession.Tags)
+	})
 }
 
+// Delete removes the session, invoiced and paid sessions are refused with
+// ErrSessionLocked.
 func (repo *GORMSessionRepository) Delete(session *models.Session, dryRun bool) error {
+	err := repo.checkLocked(session.ID)
+	if err != nil {
+		return err
+	}
+	return repo.delete(session, dryRun)
+}
+
+// ForceDelete removes the session regardless of its status.
+func (repo *GORMSessionRepository) ForceDelete(session *models.Session, dryRun bool) error {
+	return repo.delete(session, dryRun)
+}
+
+// SetStatus changes only the billing status of the session, which is allowed
+// for locked sessions as well.
+func (repo *GORMSessionRepository) SetStatus(session *models.Session, status models.SessionStatus) error {
+	err := repo.db.Model(&RepoSession{}).
+		Where("id = ?", session.ID).
+		Update("status", string(status)).Error
+	if err != nil {
+		return err
+	}
+	session.Status = status
+	return nil
+}
+
+func (repo *GORMSessionRepository) delete(session *models.Session, dryRun bool) error {
 	repoSession := ToRepoSession(*session)
 	if dryRun {
 		return repo.db.Session(&gorm.Session{DryRun: true}).Delete(&repoSession).Error
 	}
-	return repo.db.Delete(&repoSession).Error
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("session_id = ?", repoSession.ID).Delete(&RepoBreak{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&repoSession).Association("Tags").Clear()
+		if err != nil {
+			return err
+		}
+		// the UUID is taken from the stored session, the given one may lack it
+		var stored RepoSession
+		err = tx.Select("uuid").Where("id = ?", repoSession.ID).First(&stored).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		err = tx.Omit("Project", "Breaks", "Tags").Delete(&repoSession).Error
+		if err != nil {
+			return err
+		}
+		return tx.Save(&RepoTombstone{
+			SessionID:   repoSession.ID,
+			SessionUUID: stored.UUID,
+			DeletedAt:   time.Now(),
+		}).Error
+	})
+}
+
+// GetTombstones returns the tombstones of the deleted sessions.
+func (repo *GORMSessionRepository) GetTombstones() ([]models.Tombstone, error) {
+	var repoTombstones []RepoTombstone
+	err := repo.db.Order("session_id").Find(&repoTombstones).Error
+	if err != nil {
+		return nil, err
+	}
+	var tombstones []models.Tombstone
+	for _, repoTombstone := range repoTombstones {
+		tombstones = append(tombstones, models.Tombstone{
+			SessionID:   repoTombstone.SessionID,
+			SessionUUID: repoTombstone.SessionUUID,
+			DeletedAt:   repoTombstone.DeletedAt.In(time.Local),
+		})
+	}
+	return tombstones, nil
+}
+
+// DeleteTombstones forgets the tombstones of the given sessions, once no remote
+// holds them anymore.
+func (repo *GORMSessionRepository) DeleteTombstones(sessionIDs []uint32) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	return repo.db.Where("session_id IN ?", sessionIDs).Delete(&RepoTombstone{}).Error
+}
+
+// replaceBreaks overwrites the stored breaks of a session, since gorm does
+// not remove associations that are missing from the updated record.
+func replaceBreaks(tx *gorm.DB, repoSession *RepoSession) error {
+	err := tx.Where("session_id = ?", repoSession.ID).Delete(&RepoBreak{}).Error
+	if err != nil {
+		return err
+	}
+	if len(repoSession.Breaks) == 0 {
+		return nil
+	}
+	for i := range repoSession.Breaks {
+		repoSession.Breaks[i].ID = 0
+		repoSession.Breaks[i].SessionID = repoSession.ID
+	}
+	return tx.Create(&repoSession.Breaks).Error
 }
 
 func (repo *GORMSessionRepository) GetAllSessions(client models.Client) (*[]models.Session, error) {
 	var repoSessions []RepoSession
-	err := repo.db.Preload("Client").
+	err := repo.preload().
 		Where("client_name = ?", client.Name).
 		Order("start DESC").
 		Find(&repoSessions).Error
@@ -192,7 +470,7 @@ func (repo *GORMSessionRepository) GetAllSessions(client models.Client) (*[]mode
 
 func (repo *GORMSessionRepository) GetAllSessionsBetweenDates(start time.Time, end time.Time) (*[]models.Session, error) {
 	var repoSessions []RepoSession
-	err := repo.db.Preload("Client").
+	err := repo.preload().
 		Where("start >= ?", start).
 		Where(
 			repo.db.Where("end < ?", end).
@@ -215,16 +493,34 @@ func (repo *GORMSessionRepository) GetAllSessionsBetweenDates(start time.Time, e
 	return &sessions, nil
 }
 
+// GetAllSessionsStartedBetween returns the sessions that started within
+// [start, end), however late they ended.
+func (repo *GORMSessionRepository) GetAllSessionsStartedBetween(start time.Time, end time.Time) (*[]models.Session, error) {
+	var repoSessions []RepoSession
+	err := repo.preload().
+		Where("start >= ? AND start < ?", start, end).
+		Order("start DESC").
+		Find(&repoSessions).Error
+	if err != nil {
+		return nil, err
+	}
+	sessions := []models.Session{}
+	for _, repoSession := range repoSessions {
+		sessions = append(sessions, ToDomainSession(repoSession))
+	}
+	return &sessions, nil
+}
+
 func (repo *GORMSessionRepository) GetLastSessions(count uint32, client *models.Client) (*[]models.Session, error) {
 	var repoSessions []RepoSession
 	var err error
 	if client == nil {
-		err = repo.db.Preload("Client").
+		err = repo.preload().
 			Order("start DESC").
 			Limit(int(count)).
 			Find(&repoSessions).Error
 	} else {
-		err = repo.db.Preload("Client").
+		err = repo.preload().
 			Where("client_name = ?", client.Name).
 			Order("start DESC").
 			Limit(int(count)).
@@ -246,7 +542,7 @@ func (repo *GORMSessionRepository) GetLastSessions(count uint32, client *models.
 
 func (repo *GORMSessionRepository) GetAllSessionsAllClients() (*[]models.Session, error) {
 	var repoSessions []RepoSession
-	err := repo.db.Preload("Client").
+	err := repo.preload().
 		Order("start DESC").
 		Find(&repoSessions).Error
 	if err != nil {
@@ -274,13 +570,32 @@ func ToRepoSession(session models.Session) RepoSession {
 		endTime = endTime.Truncate(time.Second)
 	}
 
+	var breaks []RepoBreak
+	for _, b := range session.Breaks {
+		breakEnd := b.End
+		if b.Finished() {
+			breakEnd = breakEnd.Truncate(time.Second)
+		}
+		breaks = append(breaks, RepoBreak{
+			SessionID: session.ID,
+			Start:     b.Start.Truncate(time.Second),
+			End:       breakEnd,
+		})
+	}
+
 	return RepoSession{
-		ID:         session.ID,
-		ClientName: clientName,
-		Start:      startTime,
-		End:        endTime,
-		Note:       session.Note,
-		Client:     *ToRepoClient(session.Client),
+		ID:            session.ID,
+		UUID:          session.UUID,
+		ClientName:    clientName,
+		ProjectName:   session.ProjectName(),
+		Start:         startTime,
+		End:           endTime,
+		Note:          session.Note,
+		InvoiceNumber: session.InvoiceNumber,
+		Status:        string(session.Status),
+		Client:        *ToRepoClient(session.Client),
+		Breaks:        breaks,
+		Tags:          ToRepoTags(models.NormalizeTags(session.Tags)),
 	}
 }
 
@@ -290,11 +605,37 @@ func ToDomainSession(repoSession RepoSession) models.Session {
 	if repoSession.End != models.NULL_TIME {
 		endTime = repoSession.End.In(time.Local)
 	}
+	var breaks []models.Break
+	for _, repoBreak := range repoSession.Breaks {
+		var breakEnd time.Time
+		if repoBreak.End != models.NULL_TIME {
+			breakEnd = repoBreak.End.In(time.Local)
+		}
+		breaks = append(breaks, models.Break{
+			Start: repoBreak.Start.In(time.Local),
+			End:   breakEnd,
+		})
+	}
+	client := ToDomainClient(repoSession.Client)
+	var project *models.Project
+	if repoSession.ProjectName != "" {
+		project = &models.Project{
+			Name:   repoSession.ProjectName,
+			Client: client,
+			PPH:    repoSession.Project.PPH,
+		}
+	}
 	return models.Session{
-		ID:     repoSession.ID,
-		Client: ToDomainClient(repoSession.Client),
-		Start:  startTime,
-		End:    endTime,
-		Note:   repoSession.Note,
+		ID:            repoSession.ID,
+		UUID:          repoSession.UUID,
+		Client:        client,
+		Project:       project,
+		Start:         startTime,
+		End:           endTime,
+		Note:          repoSession.Note,
+		Breaks:        breaks,
+		Tags:          models.NormalizeTags(ToDomainTags(repoSession.Tags)),
+		InvoiceNumber: repoSession.InvoiceNumber,
+		Status:        models.SessionStatus(repoSession.Status),
 	}
 }
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"gorm.io/gorm"
+)
+
+// RepoSyncSnapshot is the version of a session a remote held after the last
+// sync with it, the base of the three-way merge of the next sync. Only the
+// fields that are synced back from remotes are kept.
+type RepoSyncSnapshot struct {
+	Remote     string `gorm:"primaryKey"`
+	SessionID  uint32 `gorm:"primaryKey"`
+	ClientName string
+	Start      time.Time
+	End        time.Time
+	Note       string
+}
+
+type GORMSyncSnapshotRepository struct {
+	db *gorm.DB
+}
+
+func NewGORMSyncSnapshotRepository(db *gorm.DB) *GORMSyncSnapshotRepository {
+	return &GORMSyncSnapshotRepository{db}
+}
+
+func (repo *GORMSyncSnapshotRepository) GetAll(remote string) ([]models.Session, error) {
+	var snapshots []RepoSyncSnapshot
+	err := repo.db.Where("remote = ?", remote).Order("session_id").Find(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+	var sessions []models.Session
+	for _, snapshot := range snapshots {
+		sessions = append(sessions, ToDomainSyncSnapshot(snapshot))
+	}
+	return sessions, nil
+}
+
+// Save stores the sessions as the remote's snapshot, replacing the previous
+// snapshot of the same sessions. Sessions without an ID are not stored.
+func (repo *GORMSyncSnapshotRepository) Save(remote string, sessions []models.Session) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		for _, session := range sessions {
+			if session.ID == 0 {
+				continue
+			}
+			snapshot := ToRepoSyncSnapshot(remote, session)
+			err := tx.Save(&snapshot).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes the snapshot of sessions that no longer exist on the remote.
+func (repo *GORMSyncSnapshotRepository) Delete(remote string, sessionIDs []uint32) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	return repo.db.Where("remote = ? AND session_id IN ?", remote, sessionIDs).
+		Delete(&RepoSyncSnapshot{}).Error
+}
+
+func ToRepoSyncSnapshot(remote string, session models.Session) RepoSyncSnapshot {
+	return RepoSyncSnapshot{
+		Remote:     remote,
+		SessionID:  session.ID,
+		ClientName: session.Client.Name,
+		Start:      session.Start,
+		End:        session.End,
+		Note:       session.Note,
+	}
+}
+
+func ToDomainSyncSnapshot(snapshot RepoSyncSnapshot) models.Session {
+	session := models.Session{
+		ID:     snapshot.SessionID,
+		Client: models.Client{Name: snapshot.ClientName},
+		Start:  snapshot.Start.In(time.Local),
+		Note:   snapshot.Note,
+	}
+	if !snapshot.End.IsZero() {
+		session.End = snapshot.End.In(time.Local)
+	}
+	return session
+}
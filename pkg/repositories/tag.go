@@ -0,0 +1,21 @@
+package repositories
+
+type RepoTag struct {
+	Name string `gorm:"primaryKey;collate:NOCASE"`
+}
+
+func ToRepoTags(tags []string) []RepoTag {
+	var repoTags []RepoTag
+	for _, tag := range tags {
+		repoTags = append(repoTags, RepoTag{Name: tag})
+	}
+	return repoTags
+}
+
+func ToDomainTags(repoTags []RepoTag) []string {
+	var tags []string
+	for _, repoTag := range repoTags {
+		tags = append(tags, repoTag.Name)
+	}
+	return tags
+}
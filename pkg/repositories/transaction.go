@@ -0,0 +1,29 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// Repositories are the repositories of a single database, for changes that
+// span several of them.
+type Repositories struct {
+	Session SessionRepository
+	Client  ClientRepository
+	Project ProjectRepository
+	Invoice InvoiceRepository
+}
+
+func NewGORMRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Session: NewGORMSessionRepository(db),
+		Client:  NewGORMClientRepository(db),
+		Project: NewGORMProjectRepository(db),
+		Invoice: NewGORMInvoiceRepository(db),
+	}
+}
+
+// Transaction runs fn with repositories bound to a single database
+// transaction, which is rolled back if fn returns an error.
+func Transaction(db *gorm.DB, fn func(repos Repositories) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewGORMRepositories(tx))
+	})
+}
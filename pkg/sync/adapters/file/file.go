@@ -0,0 +1,413 @@
+package file
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dormunis/punch/pkg/config"
+	"github.com/dormunis/punch/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FORMAT_CSV  = "csv"
+	FORMAT_JSON = "json"
+	FORMAT_YAML = "yaml"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+var csvHeader = []string{"id", "uuid", "client", "project", "start", "end", "breaks", "tags", "status", "note"}
+
+// File holds sessions on disk, times are stored in RFC 3339 so that the file
+// can be shared between machines in different time zones.
+type File struct {
+	Path   string
+	Format string
+}
+
+// Record is the schema of a session in the file. CSV files hold the breaks as
+// start/end intervals separated by semicolons, and the tags separated by commas.
+type Record struct {
+	ID      uint32        `json:"id" yaml:"id"`
+	UUID    string        `json:"uuid" yaml:"uuid"`
+	Client  string        `json:"client" yaml:"client"`
+	Project string        `json:"project" yaml:"project"`
+	Start   string        `json:"start" yaml:"start"`
+	End     *string       `json:"end" yaml:"end"`
+	Breaks  []BreakRecord `json:"breaks" yaml:"breaks"`
+	Tags    []string      `json:"tags" yaml:"tags"`
+	Status  string        `json:"status" yaml:"status"`
+	Note    string        `json:"note" yaml:"note"`
+}
+
+type BreakRecord struct {
+	Start string  `json:"start" yaml:"start"`
+	End   *string `json:"end" yaml:"end"`
+}
+
+func NewFile(cfg config.FileRemote) (*File, error) {
+	if cfg.Format != FORMAT_CSV && cfg.Format != FORMAT_JSON {
+		return nil, fmt.Errorf("%w `%s`, must be csv or json", ErrUnsupportedFormat, cfg.Format)
+	}
+	return &File{
+		Path:   cfg.Path,
+		Format: cfg.Format,
+	}, nil
+}
+
+// Read returns the sessions stored in the file, a file that does not exist
+// yet holds no sessions.
+func (f *File) Read() ([]models.Session, error) {
+	return ReadSessions(f.Path, f.Format)
+}
+
+// Write replaces the content of the file with the given sessions.
+func (f *File) Write(sessions []models.Session) error {
+	return WriteSessions(f.Path, f.Format, sessions)
+}
+
+// ReadSessions returns the sessions stored in a file of the given format, a
+// file that does not exist yet holds no sessions.
+func ReadSessions(path string, format string) ([]models.Session, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sessions, err := DecodeSessions(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	return sessions, nil
+}
+
+// WriteSessions replaces the content of a file with the given sessions. The
+// file is written aside and then renamed over the previous one, so that a
+// sync client never picks up a partially written file.
+func WriteSessions(path string, format string, sessions []models.Session) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), ".punch-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	err = EncodeSessions(temp, format, sessions)
+	if err != nil {
+		temp.Close()
+		return err
+	}
+	err = temp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
+
+func DecodeSessions(r io.Reader, format string) ([]models.Session, error) {
+	var records []Record
+	var err error
+	switch format {
+	case FORMAT_CSV:
+		records, err = readCSV(r)
+	case FORMAT_JSON:
+		records, err = readJSON(r)
+	case FORMAT_YAML:
+		records, err = readYAML(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.Session, 0, len(records))
+	for i, record := range records {
+		session, err := record.ToSession()
+		if err != nil {
+			return nil, fmt.Errorf("session %d: %w", i+1, err)
+		}
+		sessions = append(sessions, *session)
+	}
+	return sessions, nil
+}
+
+func EncodeSessions(w io.Writer, format string, sessions []models.Session) error {
+	records := make([]Record, 0, len(sessions))
+	for _, session := range sessions {
+		records = append(records, NewRecord(session))
+	}
+	switch format {
+	case FORMAT_CSV:
+		return writeCSV(w, records)
+	case FORMAT_JSON:
+		return writeJSON(w, records)
+	case FORMAT_YAML:
+		return writeYAML(w, records)
+	}
+	return ErrUnsupportedFormat
+}
+
+func NewRecord(session models.Session) Record {
+	record := Record{
+		ID:      session.ID,
+		UUID:    session.UUID,
+		Client:  session.Client.Name,
+		Project: session.ProjectName(),
+		Start:   formatTime(session.Start),
+		End:     formatEnd(session.End),
+		Breaks:  []BreakRecord{},
+		Tags:    models.NormalizeTags(session.Tags),
+		Status:  session.Status.String(),
+		Note:    session.Note,
+	}
+	if record.Tags == nil {
+		record.Tags = []string{}
+	}
+	for _, b := range session.Breaks {
+		record.Breaks = append(record.Breaks, BreakRecord{
+			Start: formatTime(b.Start),
+			End:   formatEnd(b.End),
+		})
+	}
+	return record
+}
+
+func (r Record) ToSession() (*models.Session, error) {
+	if r.Client == "" {
+		return nil, errors.New("missing client")
+	}
+	client := models.Client{Name: r.Client}
+
+	start, err := parseTime(r.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start `%s`", r.Start)
+	}
+	end, err := parseEnd(r.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end `%s`", *r.End)
+	}
+
+	var breaks []models.Break
+	for _, b := range r.Breaks {
+		breakStart, err := parseTime(b.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid break start `%s`", b.Start)
+		}
+		breakEnd, err := parseEnd(b.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid break end `%s`", *b.End)
+		}
+		breaks = append(breaks, models.Break{Start: breakStart, End: breakEnd})
+	}
+
+	var status models.SessionStatus
+	if r.Status != "" {
+		status, err = models.ParseSessionStatus(r.Status)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var project *models.Project
+	if r.Project != "" {
+		project = &models.Project{Name: r.Project, Client: client}
+	}
+
+	return &models.Session{
+		ID:      r.ID,
+		UUID:    r.UUID,
+		Client:  client,
+		Project: project,
+		Start:   start,
+		End:     end,
+		Note:    r.Note,
+		Breaks:  breaks,
+		Tags:    models.NormalizeTags(r.Tags),
+		Status:  status,
+	}, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func formatEnd(end time.Time) *string {
+	if end.Equal(models.NULL_TIME) {
+		return nil
+	}
+	formatted := formatTime(end)
+	return &formatted
+}
+
+// parseTime reads an RFC 3339 time into the local time zone, so that it
+// compares equal with the sessions of the local database.
+func parseTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return models.NULL_TIME, err
+	}
+	return t.In(time.Local), nil
+}
+
+func parseEnd(value *string) (time.Time, error) {
+	if value == nil || *value == "" {
+		return models.NULL_TIME, nil
+	}
+	return parseTime(*value)
+}
+
+func readJSON(r io.Reader) ([]Record, error) {
+	var records []Record
+	err := json.NewDecoder(r).Decode(&records)
+	if err == io.EOF {
+		return nil, nil
+	}
+	return records, err
+}
+
+func writeJSON(w io.Writer, records []Record) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+func readYAML(r io.Reader) ([]Record, error) {
+	var records []Record
+	err := yaml.NewDecoder(r).Decode(&records)
+	if err == io.EOF {
+		return nil, nil
+	}
+	return records, err
+}
+
+func writeYAML(w io.Writer, records []Record) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	err := encoder.Encode(records)
+	if err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func readCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	indexes := make(map[string]int)
+	for i, name := range rows[0] {
+		indexes[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"client", "start"} {
+		if _, ok := indexes[name]; !ok {
+			return nil, fmt.Errorf("missing column `%s`", name)
+		}
+	}
+
+	records := make([]Record, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		field := func(name string) string {
+			index, ok := indexes[name]
+			if !ok {
+				return ""
+			}
+			return row[index]
+		}
+
+		var id uint32
+		if field("id") != "" {
+			parsed, err := strconv.ParseUint(field("id"), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid id `%s`", i+2, field("id"))
+			}
+			id = uint32(parsed)
+		}
+
+		record := Record{
+			ID:      id,
+			UUID:    field("uuid"),
+			Client:  field("client"),
+			Project: field("project"),
+			Start:   field("start"),
+			Status:  field("status"),
+			Note:    field("note"),
+		}
+		if field("end") != "" {
+			end := field("end")
+			record.End = &end
+		}
+		if field("tags") != "" {
+			record.Tags = strings.Split(field("tags"), ",")
+		}
+		if field("breaks") != "" {
+			for _, interval := range strings.Split(field("breaks"), ";") {
+				breakStart, breakEnd, _ := strings.Cut(interval, "/")
+				breakRecord := BreakRecord{Start: breakStart}
+				if breakEnd != "" {
+					breakRecord.End = &breakEnd
+				}
+				record.Breaks = append(record.Breaks, breakRecord)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func writeCSV(w io.Writer, records []Record) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		end := ""
+		if record.End != nil {
+			end = *record.End
+		}
+		breaks := make([]string, 0, len(record.Breaks))
+		for _, b := range record.Breaks {
+			interval := b.Start + "/"
+			if b.End != nil {
+				interval += *b.End
+			}
+			breaks = append(breaks, interval)
+		}
+		err = writer.Write([]string{
+			strconv.FormatUint(uint64(record.ID), 10),
+			record.UUID,
+			record.Client,
+			record.Project,
+			record.Start,
+			end,
+			strings.Join(breaks, ";"),
+			strings.Join(record.Tags, ","),
+			record.Status,
+			record.Note,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
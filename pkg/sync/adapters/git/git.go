@@ -0,0 +1,240 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dormunis/punch/pkg/config"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/sync/adapters/file"
+)
+
+const monthLayout = "2006-01"
+
+var monthFilePattern = regexp.MustCompile(`^\d{4}-\d{2}\.(yaml|json)$`)
+
+// Repository is a local clone of the git repository holding the sessions,
+// with a file per month named after it (e.g. 2026-10.yaml).
+type Repository struct {
+	Path   string
+	URL    string
+	Branch string
+	Format string
+}
+
+func NewRepository(cfg config.GitRemote) (*Repository, error) {
+	if cfg.Format != file.FORMAT_YAML && cfg.Format != file.FORMAT_JSON {
+		return nil, fmt.Errorf("%w `%s`, must be yaml or json", file.ErrUnsupportedFormat, cfg.Format)
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, errors.New("git executable not found")
+	}
+	repository := &Repository{
+		Path:   cfg.Path,
+		URL:    cfg.URL,
+		Branch: cfg.Branch,
+		Format: cfg.Format,
+	}
+	err := repository.cloneIfNeeded()
+	if err != nil {
+		return nil, err
+	}
+	return repository, nil
+}
+
+func (r *Repository) cloneIfNeeded() error {
+	_, err := os.Stat(filepath.Join(r.Path, ".git"))
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if r.URL == "" {
+		return fmt.Errorf("%s is not a git repository and no url is set to clone it from", r.Path)
+	}
+
+	args := []string{"clone"}
+	if r.Branch != "" {
+		args = append(args, "--branch", r.Branch)
+	}
+	_, err = runGit("", append(args, r.URL, r.Path)...)
+	return err
+}
+
+// Pull rebases the clone on its upstream branch, a clone without an upstream
+// branch (e.g. of an empty repository) is left as is.
+func (r *Repository) Pull() error {
+	if !r.hasUpstream() {
+		return nil
+	}
+	_, err := r.git("pull", "--rebase")
+	if err != nil {
+		_, _ = r.git("rebase", "--abort")
+		return fmt.Errorf("unable to pull %s, resolve it manually: %w", r.Path, err)
+	}
+	return nil
+}
+
+// Read returns the sessions of all the month files in the working tree.
+func (r *Repository) Read() ([]models.Session, error) {
+	entries, err := os.ReadDir(r.Path)
+	if err != nil {
+		return nil, err
+	}
+	var sessions []models.Session
+	for _, entry := range entries {
+		if entry.IsDir() || !r.isMonthFile(entry.Name()) {
+			continue
+		}
+		monthSessions, err := file.ReadSessions(filepath.Join(r.Path, entry.Name()), r.Format)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, monthSessions...)
+	}
+	return sessions, nil
+}
+
+// Write replaces the month files of the given sessions, each with the sessions
+// that started in that month. Month files left without sessions are removed.
+func (r *Repository) Write(sessions []models.Session) error {
+	months := make(map[string][]models.Session)
+	for _, session := range sessions {
+		month := session.Start.Format(monthLayout)
+		months[month] = append(months[month], session)
+	}
+	names, err := r.monthFiles()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, ok := months[strings.TrimSuffix(name, "."+r.Format)]; ok {
+			continue
+		}
+		err := os.Remove(filepath.Join(r.Path, name))
+		if err != nil {
+			return err
+		}
+	}
+	for month, monthSessions := range months {
+		sort.SliceStable(monthSessions, func(i, j int) bool {
+			return monthSessions[i].Start.Before(monthSessions[j].Start)
+		})
+		path := filepath.Join(r.Path, month+"."+r.Format)
+		err := file.WriteSessions(path, r.Format, monthSessions)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit commits the month files, including the removed ones, it reports
+// whether there was anything to commit.
+func (r *Repository) Commit(message string) (bool, error) {
+	names, err := r.monthFiles()
+	if err != nil {
+		return false, err
+	}
+	out, err := r.git("ls-files")
+	if err != nil {
+		return false, err
+	}
+	for _, name := range strings.Fields(out) {
+		if r.isMonthFile(name) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		_, err = r.git(append([]string{"add", "-A", "--"}, names...)...)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	_, err = r.git("diff", "--cached", "--quiet")
+	if err == nil {
+		return false, nil
+	}
+	_, err = r.git("commit", "--quiet", "-m", message)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Push pushes the commits to the upstream branch, or sets the current branch
+// of origin as the upstream when there is none yet. A clone without an origin
+// is only committed to.
+func (r *Repository) Push() error {
+	if r.hasUpstream() {
+		out, err := r.git("rev-list", "--count", "@{upstream}..HEAD")
+		if err != nil || strings.TrimSpace(out) == "0" {
+			return err
+		}
+		_, err = r.git("push", "--quiet")
+		return err
+	}
+	if _, err := r.git("rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return nil
+	}
+	if _, err := r.git("remote", "get-url", "origin"); err != nil {
+		return nil
+	}
+	_, err := r.git("push", "--quiet", "--set-upstream", "origin", "HEAD")
+	return err
+}
+
+func (r *Repository) hasUpstream() bool {
+	_, err := r.git("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	return err == nil
+}
+
+// monthFiles returns the names of the month files in the working tree.
+func (r *Repository) monthFiles() ([]string, error) {
+	entries, err := os.ReadDir(r.Path)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && r.isMonthFile(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (r *Repository) isMonthFile(name string) bool {
+	return monthFilePattern.MatchString(name) && strings.HasSuffix(name, "."+r.Format)
+}
+
+func (r *Repository) git(args ...string) (string, error) {
+	return runGit(r.Path, args...)
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], message)
+	}
+	return stdout.String(), nil
+}
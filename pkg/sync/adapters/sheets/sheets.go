@@ -18,6 +18,7 @@ import (
 
 type Columns struct {
 	ID        string
+	UUID      string
 	Client    string
 	Date      string
 	StartTime string
@@ -34,12 +35,14 @@ type Sheet struct {
 }
 
 type Record struct {
-	Session models.Session
-	Row     int
+	Session   models.Session
+	Row       int
+	TotalTime string
 }
 
 var (
 	idColumnIndex        int
+	uuidColumnIndex      = -1
 	clientColumnIndex    int
 	dateColumnIndex      int
 	startTimeColumnIndex int
@@ -95,6 +98,8 @@ func GetSheet(srv *sheets.Service, cfg config.SpreadsheetRemote) (*Sheet, error)
 		SpreadsheetId: cfg.ID,
 		SheetName:     cfg.SheetName,
 		Columns: Columns{
+			ID:        cfg.Columns.ID,
+			UUID:      cfg.Columns.UUID,
 			Client:    cfg.Columns.Client,
 			Date:      cfg.Columns.Date,
 			StartTime: cfg.Columns.StartTime,
@@ -127,6 +132,9 @@ func (s *Sheet) ParseSheet(records *[]Record) error {
 			return err
 		}
 		record := Record{Session: *session, Row: i}
+		if len(row) > totalTimeColumnIndex {
+			record.TotalTime, _ = row[totalTimeColumnIndex].(string)
+		}
 		*records = append(*records, record)
 	}
 	return nil
@@ -134,12 +142,14 @@ func (s *Sheet) ParseSheet(records *[]Record) error {
 
 func (s *Sheet) SessionToRow(session models.Session) []any {
 	maxIdx := max(clientColumnIndex, dateColumnIndex, startTimeColumnIndex,
-		endTimeColumnIndex, totalTimeColumnIndex, noteColumnIndex) + 1
+		endTimeColumnIndex, totalTimeColumnIndex, noteColumnIndex, uuidColumnIndex) + 1
 	row := make([]any, maxIdx)
 	for i := range row {
 		switch i {
 		case idColumnIndex:
 			row[idColumnIndex] = strconv.FormatUint(uint64(session.ID), 10)
+		case uuidColumnIndex:
+			row[uuidColumnIndex] = session.UUID
 		case clientColumnIndex:
 			row[clientColumnIndex] = session.Client.Name
 		case dateColumnIndex:
@@ -205,6 +215,11 @@ func (s *Sheet) SessionFromRow(row []any) (*models.Session, error) {
 		endTime = endTime.AddDate(0, 0, 1)
 	}
 
+	var sessionUUID string
+	if s.HasUUIDColumn() && len(row) > uuidColumnIndex {
+		sessionUUID, _ = row[uuidColumnIndex].(string)
+	}
+
 	var note string
 	if len(row) > noteColumnIndex {
 		note = row[noteColumnIndex].(string)
@@ -214,6 +229,7 @@ func (s *Sheet) SessionFromRow(row []any) (*models.Session, error) {
 
 	session := models.Session{
 		ID:     id,
+		UUID:   sessionUUID,
 		Client: models.Client{Name: row[clientColumnIndex].(string)},
 		Start:  startTime,
 		End:    endTime,
@@ -245,11 +261,25 @@ func (s *Sheet) UpdateRow(record Record) error {
 	return err
 }
 
+// ClearRow empties the row of a record, empty rows are skipped when the sheet
+// is parsed.
+func (s *Sheet) ClearRow(record Record) error {
+	// Adding one because of the header row
+	rangeToClear := fmt.Sprintf("%s!%d:%d", s.SheetName, record.Row+1, record.Row+1)
+	_, err := s.Service.Spreadsheets.Values.Clear(s.SpreadsheetId, rangeToClear, &sheets.ClearValuesRequest{}).Do()
+	return err
+}
+
 func (s *Sheet) ParseHeaders(row []any) {
+	uuidColumnIndex = -1
 	for i, column := range row {
 		switch column {
 		case s.Columns.ID:
 			idColumnIndex = i
+		case s.Columns.UUID:
+			if s.Columns.UUID != "" {
+				uuidColumnIndex = i
+			}
 		case s.Columns.Client:
 			clientColumnIndex = i
 		case s.Columns.Date:
@@ -266,6 +296,12 @@ func (s *Sheet) ParseHeaders(row []any) {
 	}
 }
 
+// HasUUIDColumn reports whether the sheet has the configured UUID column, sheets
+// without one are matched by ID.
+func (s *Sheet) HasUUIDColumn() bool {
+	return uuidColumnIndex >= 0
+}
+
 func (s *Sheet) readSheet() (*sheets.ValueRange, error) {
 	resp, err := s.Service.Spreadsheets.Values.Get(s.SpreadsheetId, s.SheetName).Do()
 	if err != nil {
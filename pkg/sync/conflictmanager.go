@@ -28,13 +28,22 @@ func GetConflicts(localSessions, remoteSessions []models.Session) (*bytes.Buffer
 	if err != nil {
 		return nil, err
 	}
+	return conflicts.Diff()
+}
+
+// Diff lays out the local and remote versions of the conflicting sessions in
+// git's conflict markers, it is empty when there are no conflicts.
+func (c ConflictingSessions) Diff() (*bytes.Buffer, error) {
+	if len(c.Local) == 0 {
+		return new(bytes.Buffer), nil
+	}
 
-	localBuffer, err := models.SerializeSessionsToYAML(conflicts.Local)
+	localBuffer, err := models.SerializeSessionsToYAML(c.Local)
 	if err != nil {
 		return nil, err
 	}
 
-	remoteBuffer, err := models.SerializeSessionsToYAML(conflicts.Remote)
+	remoteBuffer, err := models.SerializeSessionsToYAML(c.Remote)
 	if err != nil {
 		return nil, err
 	}
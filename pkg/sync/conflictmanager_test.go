@@ -155,3 +155,11 @@ func TestConflictManager_GetConflicts_SameIDDiffererentCompanyMismatch(t *testin
 	assert.NotNil(t, buf)
 	assert.NotEmpty(t, buf.String(), "Buffer should show conflict for same ID but different companies")
 }
+
+func TestConflictManager_GetConflicts_NoConflictsIsEmpty(t *testing.T) {
+	local := []models.Session{{ID: 1, Start: time.Now()}}
+
+	buf, err := GetConflicts(local, nil)
+	assert.NoError(t, err)
+	assert.Zero(t, buf.Len())
+}
@@ -0,0 +1,175 @@
+package sync
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/dormunis/punch/pkg/sync/adapters/file"
+)
+
+type FileSyncSource struct {
+	File              *file.File
+	SessionRepository repositories.SessionRepository
+
+	cachedData  []models.Session
+	isDataFresh bool
+}
+
+func (f *FileSyncSource) Type() string {
+	return "file"
+}
+
+func (f *FileSyncSource) readFileIfNeeded() error {
+	if f.isDataFresh {
+		return nil
+	}
+
+	sessions, err := f.File.Read()
+	if err != nil {
+		return err
+	}
+	f.cachedData = sessions
+	f.isDataFresh = true
+	return nil
+}
+
+func (f *FileSyncSource) Pull() ([]models.Session, error) {
+	err := f.readFileIfNeeded()
+	if err != nil {
+		return nil, err
+	}
+	return withLocalIDs(f.SessionRepository, f.cachedData)
+}
+
+// Push merges the local sessions into the file. Sessions that only exist in
+// the file are kept, and the file is only rewritten when something changed.
+func (f *FileSyncSource) Push(sessions *[]models.Session, approvedDiffs *[]models.Session) (PushSummary, error) {
+	err := f.readFileIfNeeded()
+	if err != nil {
+		return PushSummary{}, err
+	}
+
+	merged, summary, err := mergeSessions(f.cachedData, *sessions, *approvedDiffs)
+	if err != nil {
+		return PushSummary{}, err
+	}
+	if summary.Added+summary.Updated == 0 {
+		return summary, nil
+	}
+
+	err = f.File.Write(merged)
+	if err != nil {
+		return PushSummary{}, err
+	}
+	f.cachedData = merged
+	return summary, nil
+}
+
+// Delete removes the given sessions from the file.
+func (f *FileSyncSource) Delete(sessions *[]models.Session) (PushSummary, error) {
+	err := f.readFileIfNeeded()
+	if err != nil {
+		return PushSummary{}, err
+	}
+
+	remaining, summary := removeSessions(f.cachedData, *sessions)
+	if summary.Deleted == 0 {
+		return summary, nil
+	}
+	err = f.File.Write(remaining)
+	if err != nil {
+		return PushSummary{}, err
+	}
+	f.cachedData = remaining
+	return summary, nil
+}
+
+// removeSessions returns the remote sessions without the given ones, which
+// are matched by UUID, or by ID when either has no UUID.
+func removeSessions(remoteSessions, deletedSessions []models.Session) ([]models.Session, PushSummary) {
+	var summary PushSummary
+	remaining := make([]models.Session, 0, len(remoteSessions))
+	for _, session := range remoteSessions {
+		isDeleted := func(deleted models.Session) bool { return sameRemoteSession(session, deleted) }
+		if slices.ContainsFunc(deletedSessions, isDeleted) {
+			summary.Deleted++
+			continue
+		}
+		remaining = append(remaining, session)
+	}
+	return remaining, summary
+}
+
+// mergeSessions adds the local sessions to the remote ones, or replaces the
+// remote sessions they differ from, ordered by start time. Remote sessions
+// that conflict with a local session are only replaced once approved.
+func mergeSessions(remoteSessions, localSessions, approvedDiffs []models.Session) ([]models.Session, PushSummary, error) {
+	approved := make(map[uint32]bool)
+	for _, diff := range approvedDiffs {
+		approved[diff.ID] = true
+	}
+
+	merged := append([]models.Session(nil), remoteSessions...)
+	var summary PushSummary
+	var conflicts []models.Session
+	for _, session := range localSessions {
+		index := findRemoteSession(merged, session)
+		if index < 0 {
+			merged = append(merged, session)
+			summary.Added++
+			continue
+		}
+		remoteSession := merged[index]
+		if sameUUID(remoteSession, session) {
+			// the file may hold the session under the ID of another device
+			remoteSession.ID = session.ID
+		}
+		if remoteSession.Conflicts(session) && !approved[session.ID] {
+			fmt.Printf("Conflict (ID: %v) between local and remote sessions\n", session.ID)
+			conflicts = append(conflicts, session)
+			continue
+		}
+		if remoteSession.Equals(session) && remoteSession.Status == session.Status &&
+			remoteSession.UUID == session.UUID {
+			continue
+		}
+		merged[index] = session
+		summary.Updated++
+	}
+
+	if len(conflicts) > 0 {
+		return nil, PushSummary{}, fmt.Errorf("%d conflicts", len(conflicts))
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Start.Before(merged[j].Start)
+	})
+	return merged, summary, nil
+}
+
+// findRemoteSession returns the index of the remote session with the same
+// UUID, or with the same ID when either has no UUID, or of a similar session
+// when there is none, -1 when neither exists.
+func findRemoteSession(sessions []models.Session, session models.Session) int {
+	sameID, similar := -1, -1
+	for i, remoteSession := range sessions {
+		if sameUUID(remoteSession, session) {
+			return i
+		}
+		if remoteSession.UUID != "" && session.UUID != "" {
+			continue
+		}
+		if sameID < 0 && remoteSession.ID == session.ID {
+			sameID = i
+		}
+		if similar < 0 && remoteSession.Similar(session) {
+			similar = i
+		}
+	}
+	if sameID >= 0 {
+		return sameID
+	}
+	return similar
+}
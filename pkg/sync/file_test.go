@@ -0,0 +1,198 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/config"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/sync/adapters/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFileSyncSource(t *testing.T, name string) *FileSyncSource {
+	format := filepath.Ext(name)[1:]
+	remoteFile, err := file.NewFile(config.FileRemote{Path: filepath.Join(t.TempDir(), name), Format: format})
+	assert.NoError(t, err)
+	return &FileSyncSource{File: remoteFile}
+}
+
+func sampleFileSessions() []models.Session {
+	start := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.Local)
+	client := models.Client{Name: "Acme"}
+	return []models.Session{
+		{
+			ID:      1,
+			Client:  client,
+			Project: &models.Project{Name: "Web", Client: client},
+			Start:   start,
+			End:     start.Add(3 * time.Hour),
+			Note:    "design, review",
+			Breaks:  []models.Break{{Start: start.Add(time.Hour), End: start.Add(time.Hour + 15*time.Minute)}},
+			Tags:    []string{"dev", "ops"},
+			Status:  models.SESSION_STATUS_INVOICED,
+		},
+		{
+			ID:     2,
+			Client: models.Client{Name: "Globex"},
+			Start:  start.AddDate(0, 0, 1),
+		},
+	}
+}
+
+func TestFileSyncSource_PushThenPullRoundTrips(t *testing.T) {
+	for _, name := range []string{"sessions.csv", "sessions.json"} {
+		t.Run(name, func(t *testing.T) {
+			source := newFileSyncSource(t, name)
+			sessions := sampleFileSessions()
+
+			pulled, err := source.Pull()
+			assert.NoError(t, err)
+			assert.Empty(t, pulled)
+
+			summary, err := source.Push(&sessions, &[]models.Session{})
+			assert.NoError(t, err)
+			assert.Equal(t, 2, summary.Added)
+
+			reread, err := (&FileSyncSource{File: source.File}).Pull()
+			assert.NoError(t, err)
+			assert.Len(t, reread, 2)
+			for i := range sessions {
+				assert.True(t, sessions[i].Equals(reread[i]), "session %d differs", sessions[i].ID)
+				assert.Equal(t, sessions[i].Status, reread[i].Status)
+				assert.False(t, sessions[i].Conflicts(reread[i]))
+			}
+		})
+	}
+}
+
+func TestFileSyncSource_PushKeepsRemoteSessionsAndSkipsUnchanged(t *testing.T) {
+	source := newFileSyncSource(t, "sessions.json")
+	sessions := sampleFileSessions()
+	assert.NoError(t, source.File.Write(sessions[1:]))
+
+	_, err := source.Pull()
+	assert.NoError(t, err)
+	summary, err := source.Push(&sessions, &[]models.Session{})
+	assert.NoError(t, err)
+	assert.Equal(t, PushSummary{Added: 1}, summary)
+
+	summary, err = source.Push(&sessions, &[]models.Session{})
+	assert.NoError(t, err)
+	assert.Equal(t, PushSummary{}, summary)
+
+	edited := []models.Session{sessions[0]}
+	edited[0].Note = "edited"
+	summary, err = source.Push(&edited, &[]models.Session{})
+	assert.NoError(t, err)
+	assert.Equal(t, PushSummary{Updated: 1}, summary)
+
+	reread, err := (&FileSyncSource{File: source.File}).Pull()
+	assert.NoError(t, err)
+	assert.Len(t, reread, 2)
+	assert.Equal(t, "edited", reread[0].Note)
+}
+
+func TestFileSyncSource_PushRefusesUnapprovedConflicts(t *testing.T) {
+	source := newFileSyncSource(t, "sessions.csv")
+	sessions := sampleFileSessions()
+	assert.NoError(t, source.File.Write(sessions))
+
+	_, err := source.Pull()
+	assert.NoError(t, err)
+	local := []models.Session{sessions[0]}
+	local[0].Start = local[0].Start.Add(-time.Hour)
+
+	_, err = source.Push(&local, &[]models.Session{})
+	assert.Error(t, err)
+
+	summary, err := source.Push(&local, &local)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, summary.Updated)
+}
+
+func TestFileSyncSource_DeleteRemovesSessionsByID(t *testing.T) {
+	source := newFileSyncSource(t, "sessions.csv")
+	sessions := sampleFileSessions()
+	assert.NoError(t, source.File.Write(sessions))
+
+	similar := sessions[1]
+	similar.ID = 3
+	summary, err := source.Delete(&[]models.Session{similar})
+	assert.NoError(t, err)
+	assert.Equal(t, PushSummary{}, summary)
+
+	summary, err = source.Delete(&[]models.Session{sessions[1]})
+	assert.NoError(t, err)
+	assert.Equal(t, PushSummary{Deleted: 1}, summary)
+
+	reread, err := (&FileSyncSource{File: source.File}).Pull()
+	assert.NoError(t, err)
+	assert.Len(t, reread, 1)
+	assert.Equal(t, sessions[0].ID, reread[0].ID)
+}
+
+func TestFileSyncSource_PullInvalidFile(t *testing.T) {
+	source := newFileSyncSource(t, "sessions.csv")
+	err := os.WriteFile(source.File.Path, []byte("id,client,start\n1,Acme,yesterday\n"), 0644)
+	assert.NoError(t, err)
+
+	_, err = source.Pull()
+
+	assert.ErrorContains(t, err, "invalid start")
+}
+
+func TestFileSyncSource_PushMatchesSessionsByUUID(t *testing.T) {
+	for _, name := range []string{"sessions.csv", "sessions.json"} {
+		t.Run(name, func(t *testing.T) {
+			source := newFileSyncSource(t, name)
+			sessions := sampleFileSessions()
+			sessions[0].UUID = "local-uuid"
+			otherDevice := sessions[1]
+			otherDevice.ID = 1
+			otherDevice.UUID = "another-device-uuid"
+			assert.NoError(t, source.File.Write([]models.Session{otherDevice}))
+
+			local := sessions[:1]
+			summary, err := source.Push(&local, &[]models.Session{})
+			assert.NoError(t, err)
+			assert.Equal(t, PushSummary{Added: 1}, summary, "a colliding ID of another device is not overwritten")
+
+			synced := otherDevice
+			synced.ID = 5
+			synced.Note = "edited"
+			summary, err = source.Push(&[]models.Session{synced}, &[]models.Session{})
+			assert.NoError(t, err)
+			assert.Equal(t, PushSummary{Updated: 1}, summary)
+
+			reread, err := file.ReadSessions(source.File.Path, source.File.Format)
+			assert.NoError(t, err)
+			assert.Len(t, reread, 2)
+			assert.Equal(t, "local-uuid", reread[0].UUID)
+			assert.Equal(t, "another-device-uuid", reread[1].UUID)
+			assert.Equal(t, "edited", reread[1].Note)
+		})
+	}
+}
+
+func TestFileSyncSource_DeleteRemovesSessionsByUUID(t *testing.T) {
+	source := newFileSyncSource(t, "sessions.csv")
+	sessions := sampleFileSessions()
+	sessions[0].UUID = "local-uuid"
+	sessions[1].ID = 1
+	sessions[1].UUID = "another-device-uuid"
+	assert.NoError(t, source.File.Write(sessions))
+
+	deleted := sessions[1]
+	deleted.ID = 3
+	summary, err := source.Delete(&[]models.Session{deleted})
+	assert.NoError(t, err)
+	assert.Equal(t, PushSummary{Deleted: 1}, summary)
+
+	reread, err := file.ReadSessions(source.File.Path, source.File.Format)
+	assert.NoError(t, err)
+	assert.Len(t, reread, 1)
+	assert.Equal(t, "local-uuid", reread[0].UUID)
+}
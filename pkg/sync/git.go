@@ -0,0 +1,109 @@
+package sync
+
+import (
+	"fmt"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/dormunis/punch/pkg/sync/adapters/git"
+)
+
+type GitSyncSource struct {
+	Repository        *git.Repository
+	SessionRepository repositories.SessionRepository
+
+	cachedData  []models.Session
+	isDataFresh bool
+}
+
+func (g *GitSyncSource) Type() string {
+	return "git"
+}
+
+func (g *GitSyncSource) pullIfNeeded() error {
+	if g.isDataFresh {
+		return nil
+	}
+
+	err := g.Repository.Pull()
+	if err != nil {
+		return err
+	}
+	sessions, err := g.Repository.Read()
+	if err != nil {
+		return err
+	}
+	g.cachedData = sessions
+	g.isDataFresh = true
+	return nil
+}
+
+func (g *GitSyncSource) Pull() ([]models.Session, error) {
+	err := g.pullIfNeeded()
+	if err != nil {
+		return nil, err
+	}
+	return withLocalIDs(g.SessionRepository, g.cachedData)
+}
+
+// Push merges the local sessions into the month files, then commits and
+// pushes them. Changes left over by a sync that failed to commit or push are
+// published as well.
+func (g *GitSyncSource) Push(sessions *[]models.Session, approvedDiffs *[]models.Session) (PushSummary, error) {
+	err := g.pullIfNeeded()
+	if err != nil {
+		return PushSummary{}, err
+	}
+
+	merged, summary, err := mergeSessions(g.cachedData, *sessions, *approvedDiffs)
+	if err != nil {
+		return PushSummary{}, err
+	}
+	message := "Sync sessions"
+	if summary.Added+summary.Updated > 0 {
+		err = g.Repository.Write(merged)
+		if err != nil {
+			return PushSummary{}, err
+		}
+		g.cachedData = merged
+		message = fmt.Sprintf("Sync %d sessions", summary.Added+summary.Updated)
+	}
+
+	_, err = g.Repository.Commit(message)
+	if err != nil {
+		return PushSummary{}, err
+	}
+	err = g.Repository.Push()
+	if err != nil {
+		return PushSummary{}, err
+	}
+	return summary, nil
+}
+
+// Delete removes the given sessions from the month files, then commits and
+// pushes them.
+func (g *GitSyncSource) Delete(sessions *[]models.Session) (PushSummary, error) {
+	err := g.pullIfNeeded()
+	if err != nil {
+		return PushSummary{}, err
+	}
+
+	remaining, summary := removeSessions(g.cachedData, *sessions)
+	if summary.Deleted == 0 {
+		return summary, nil
+	}
+	err = g.Repository.Write(remaining)
+	if err != nil {
+		return PushSummary{}, err
+	}
+	g.cachedData = remaining
+	_, err = g.Repository.Commit(fmt.Sprintf("Delete %d sessions", summary.Deleted))
+	if err != nil {
+		return PushSummary{}, err
+	}
+	err = g.Repository.Push()
+	if err != nil {
+		return PushSummary{}, err
+	}
+	return summary, nil
+}
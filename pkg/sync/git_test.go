@@ -0,0 +1,141 @@
+package sync
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/dormunis/punch/pkg/config"
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/sync/adapters/git"
+	"github.com/stretchr/testify/assert"
+)
+
+// newBareRepository creates an empty bare repository to clone from, along with
+// a git identity to commit with.
+func newBareRepository(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_AUTHOR_NAME", "punch")
+	t.Setenv("GIT_AUTHOR_EMAIL", "punch@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "punch")
+	t.Setenv("GIT_COMMITTER_EMAIL", "punch@example.com")
+
+	bare := filepath.Join(t.TempDir(), "timesheets.git")
+	err := exec.Command("git", "init", "--quiet", "--bare", bare).Run()
+	assert.NoError(t, err)
+	return bare
+}
+
+func newGitSyncSource(t *testing.T, url string) *GitSyncSource {
+	repository, err := git.NewRepository(config.GitRemote{
+		Path:   filepath.Join(t.TempDir(), "clone"),
+		URL:    url,
+		Format: "yaml",
+	})
+	assert.NoError(t, err)
+	return &GitSyncSource{Repository: repository}
+}
+
+func TestGitSyncSource_PushedSessionsArePulledByAnotherClone(t *testing.T) {
+	bare := newBareRepository(t)
+	sessions := sampleFileSessions()
+	sessions[1].Start = sessions[1].Start.AddDate(0, -1, 0)
+
+	first := newGitSyncSource(t, bare)
+	pulled, err := first.Pull()
+	assert.NoError(t, err)
+	assert.Empty(t, pulled)
+	summary, err := first.Push(&sessions, &[]models.Session{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, summary.Added)
+	assert.FileExists(t, filepath.Join(first.Repository.Path, "2026-09.yaml"))
+	assert.FileExists(t, filepath.Join(first.Repository.Path, "2026-10.yaml"))
+
+	second := newGitSyncSource(t, bare)
+	pulled, err = second.Pull()
+	assert.NoError(t, err)
+	assert.Len(t, pulled, 2)
+	assert.True(t, sessions[1].Equals(pulled[0]))
+	assert.True(t, sessions[0].Equals(pulled[1]))
+	assert.Equal(t, models.SESSION_STATUS_INVOICED, pulled[1].Status)
+
+	edited := []models.Session{sessions[0]}
+	edited[0].Note = "edited on the second machine"
+	summary, err = second.Push(&edited, &[]models.Session{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, summary.Updated)
+
+	pulled, err = (&GitSyncSource{Repository: first.Repository}).Pull()
+	assert.NoError(t, err)
+	assert.Len(t, pulled, 2)
+	assert.Equal(t, "edited on the second machine", pulled[1].Note)
+}
+
+func TestGitSyncSource_UnchangedSessionsAreNotCommitted(t *testing.T) {
+	bare := newBareRepository(t)
+	sessions := sampleFileSessions()
+	source := newGitSyncSource(t, bare)
+
+	_, err := source.Push(&sessions, &[]models.Session{})
+	assert.NoError(t, err)
+	summary, err := (&GitSyncSource{Repository: source.Repository}).Push(&sessions, &[]models.Session{})
+	assert.NoError(t, err)
+	assert.Equal(t, PushSummary{}, summary)
+
+	out, err := exec.Command("git", "-C", bare, "rev-list", "--count", "HEAD").Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "1\n", string(out))
+}
+
+func TestGitSyncSource_ConflictsAreSurfaced(t *testing.T) {
+	bare := newBareRepository(t)
+	sessions := sampleFileSessions()
+	first := newGitSyncSource(t, bare)
+	_, err := first.Push(&sessions, &[]models.Session{})
+	assert.NoError(t, err)
+
+	local := []models.Session{sessions[0]}
+	local[0].Client = models.Client{Name: "Globex"}
+	second := newGitSyncSource(t, bare)
+	pulled, err := second.Pull()
+	assert.NoError(t, err)
+
+	conflicts, err := GetConflicts(local, pulled)
+	assert.NoError(t, err)
+	assert.Contains(t, conflicts.String(), "<<<<<<< HEAD")
+	_, err = second.Push(&local, &[]models.Session{})
+	assert.Error(t, err)
+}
+
+func TestGitSyncSource_DeletionsRemoveEmptyMonthFiles(t *testing.T) {
+	bare := newBareRepository(t)
+	sessions := sampleFileSessions()
+	sessions[1].Start = sessions[1].Start.AddDate(0, -1, 0)
+	first := newGitSyncSource(t, bare)
+	_, err := first.Push(&sessions, &[]models.Session{})
+	assert.NoError(t, err)
+
+	summary, err := first.Delete(&[]models.Session{sessions[1]})
+	assert.NoError(t, err)
+	assert.Equal(t, PushSummary{Deleted: 1}, summary)
+	assert.NoFileExists(t, filepath.Join(first.Repository.Path, "2026-09.yaml"))
+
+	second := newGitSyncSource(t, bare)
+	pulled, err := second.Pull()
+	assert.NoError(t, err)
+	assert.Len(t, pulled, 1)
+	assert.Equal(t, sessions[0].ID, pulled[0].ID)
+	assert.NoFileExists(t, filepath.Join(second.Repository.Path, "2026-09.yaml"))
+}
+
+func TestGitRepository_MissingCloneWithoutURL(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	_, err := git.NewRepository(config.GitRemote{Path: t.TempDir(), Format: "yaml"})
+
+	assert.ErrorContains(t, err, "not a git repository")
+}
@@ -0,0 +1,127 @@
+package sync
+
+import (
+	"sort"
+
+	"github.com/dormunis/punch/pkg/models"
+)
+
+// MergeResult is the outcome of a three-way merge of the local and remote
+// sessions. Pulled holds the local sessions updated with the remote changes,
+// Added the remote sessions created on other devices, Pushed the local
+// sessions whose changes should overwrite the remote ones, and Conflicts the
+// sessions that were changed differently on both sides.
+type MergeResult struct {
+	Pulled    []models.Session
+	Added     []models.Session
+	Pushed    []models.Session
+	Conflicts ConflictingSessions
+}
+
+// Resolved returns the sessions whose differences were resolved by the merge.
+func (r MergeResult) Resolved() []models.Session {
+	return append(append([]models.Session(nil), r.Pulled...), r.Pushed...)
+}
+
+// MergeThreeWay merges the sessions that exist both locally and remotely, using
+// the version of the last sync (the base) to tell which side changed a field.
+// Only the client, start, end and note are merged since remotes are not aware
+// of the rest. Without a base, sessions that differ in their client, start or
+// end are conflicts, and local changes to the note are pushed. Remote sessions
+// with a UUID but no local ID are unknown locally, and are added as is.
+func MergeThreeWay(baseSessions, localSessions, remoteSessions []models.Session) MergeResult {
+	bases := make(map[uint32]models.Session)
+	for _, session := range baseSessions {
+		bases[session.ID] = session
+	}
+	var result MergeResult
+	remotes := make(map[uint32]models.Session)
+	for _, session := range remoteSessions {
+		if session.ID != 0 {
+			remotes[session.ID] = session
+		} else if session.UUID != "" {
+			result.Added = append(result.Added, session)
+		}
+	}
+
+	for _, local := range localSessions {
+		remote, ok := remotes[local.ID]
+		if !ok || sameSyncedFields(local, remote) {
+			continue
+		}
+
+		base, ok := bases[local.ID]
+		if !ok {
+			if local.Conflicts(remote) {
+				result.Conflicts.Local = append(result.Conflicts.Local, local)
+				result.Conflicts.Remote = append(result.Conflicts.Remote, remote)
+			} else {
+				result.Pushed = append(result.Pushed, local)
+			}
+			continue
+		}
+
+		merged, conflict := mergeThreeWaySession(base, local, remote)
+		switch {
+		case conflict:
+			result.Conflicts.Local = append(result.Conflicts.Local, local)
+			result.Conflicts.Remote = append(result.Conflicts.Remote, remote)
+		case sameSyncedFields(merged, local):
+			result.Pushed = append(result.Pushed, local)
+		default:
+			result.Pulled = append(result.Pulled, merged)
+		}
+	}
+
+	sort.SliceStable(result.Pulled, func(i, j int) bool {
+		return result.Pulled[i].Start.Before(result.Pulled[j].Start)
+	})
+	sort.SliceStable(result.Added, func(i, j int) bool {
+		return result.Added[i].Start.Before(result.Added[j].Start)
+	})
+	return result
+}
+
+// mergeThreeWaySession takes each field from the side that changed it since
+// the base, it reports a conflict when both sides changed a field differently.
+// Local changes that the remote does not have are pushed afterwards.
+func mergeThreeWaySession(base, local, remote models.Session) (models.Session, bool) {
+	merged := local
+	conflict := false
+
+	mergeField := func(same, localChanged, remoteChanged bool, takeRemote func()) {
+		switch {
+		case same:
+		case !localChanged:
+			takeRemote()
+		case remoteChanged:
+			conflict = true
+		}
+	}
+
+	mergeField(local.Client.Name == remote.Client.Name,
+		local.Client.Name != base.Client.Name,
+		remote.Client.Name != base.Client.Name,
+		func() { merged.Client = remote.Client })
+	mergeField(local.Start.Equal(remote.Start),
+		!local.Start.Equal(base.Start),
+		!remote.Start.Equal(base.Start),
+		func() { merged.Start = remote.Start })
+	mergeField(local.End.Equal(remote.End),
+		!local.End.Equal(base.End),
+		!remote.End.Equal(base.End),
+		func() { merged.End = remote.End })
+	mergeField(local.Note == remote.Note,
+		local.Note != base.Note,
+		remote.Note != base.Note,
+		func() { merged.Note = remote.Note })
+
+	return merged, conflict
+}
+
+func sameSyncedFields(a, b models.Session) bool {
+	return a.Client.Name == b.Client.Name &&
+		a.Start.Equal(b.Start) &&
+		a.End.Equal(b.End) &&
+		a.Note == b.Note
+}
@@ -0,0 +1,125 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleMergeSession() models.Session {
+	start := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.Local)
+	return models.Session{
+		ID:     1,
+		Client: models.Client{Name: "Acme"},
+		Start:  start,
+		End:    start.Add(2 * time.Hour),
+		Note:   "design",
+	}
+}
+
+func TestMergeThreeWay_TakesRemoteOnlyChanges(t *testing.T) {
+	base := sampleMergeSession()
+	local := base
+	remote := base
+	remote.End = base.End.Add(30 * time.Minute)
+
+	result := MergeThreeWay([]models.Session{base}, []models.Session{local}, []models.Session{remote})
+
+	assert.Len(t, result.Pulled, 1)
+	assert.Equal(t, remote.End, result.Pulled[0].End)
+	assert.Empty(t, result.Pushed)
+	assert.Empty(t, result.Conflicts.Local)
+}
+
+func TestMergeThreeWay_PushesLocalOnlyChanges(t *testing.T) {
+	base := sampleMergeSession()
+	local := base
+	local.End = base.End.Add(30 * time.Minute)
+	remote := base
+
+	result := MergeThreeWay([]models.Session{base}, []models.Session{local}, []models.Session{remote})
+
+	assert.Empty(t, result.Pulled)
+	assert.Equal(t, []models.Session{local}, result.Pushed)
+	assert.Empty(t, result.Conflicts.Local)
+}
+
+func TestMergeThreeWay_MergesChangesOfDifferentFields(t *testing.T) {
+	base := sampleMergeSession()
+	local := base
+	local.Tags = []string{"dev"}
+	local.Note = "design review"
+	remote := base
+	remote.Start = base.Start.Add(-time.Hour)
+
+	result := MergeThreeWay([]models.Session{base}, []models.Session{local}, []models.Session{remote})
+
+	assert.Len(t, result.Pulled, 1)
+	assert.Equal(t, remote.Start, result.Pulled[0].Start)
+	assert.Equal(t, "design review", result.Pulled[0].Note)
+	assert.Equal(t, []string{"dev"}, result.Pulled[0].Tags)
+	assert.Len(t, result.Resolved(), 1)
+	assert.Empty(t, result.Conflicts.Local)
+}
+
+func TestMergeThreeWay_ConflictsWhenBothSidesChangeAField(t *testing.T) {
+	base := sampleMergeSession()
+	local := base
+	local.Note = "local note"
+	remote := base
+	remote.Note = "remote note"
+
+	result := MergeThreeWay([]models.Session{base}, []models.Session{local}, []models.Session{remote})
+
+	assert.Empty(t, result.Resolved())
+	assert.Equal(t, []models.Session{local}, result.Conflicts.Local)
+	assert.Equal(t, []models.Session{remote}, result.Conflicts.Remote)
+}
+
+func TestMergeThreeWay_SameChangeOnBothSidesIsNotAConflict(t *testing.T) {
+	base := sampleMergeSession()
+	local := base
+	local.End = base.End.Add(time.Hour)
+	remote := local
+
+	result := MergeThreeWay([]models.Session{base}, []models.Session{local}, []models.Session{remote})
+
+	assert.Empty(t, result.Resolved())
+	assert.Empty(t, result.Conflicts.Local)
+}
+
+func TestMergeThreeWay_WithoutBase(t *testing.T) {
+	local := sampleMergeSession()
+	notes := local
+	notes.Note = "remote note"
+	times := local
+	times.ID = 2
+	moved := times
+	moved.Start = times.Start.Add(time.Hour)
+
+	result := MergeThreeWay(nil, []models.Session{local, times}, []models.Session{notes, moved})
+
+	assert.Equal(t, []models.Session{local}, result.Pushed)
+	assert.Equal(t, []models.Session{times}, result.Conflicts.Local)
+	assert.Empty(t, result.Pulled)
+}
+
+func TestMergeThreeWay_AddsSessionsOfOtherDevices(t *testing.T) {
+	local := sampleMergeSession()
+	local.UUID = "local-uuid"
+	otherDevice := sampleMergeSession()
+	otherDevice.ID = 0
+	otherDevice.UUID = "another-device-uuid"
+	otherDevice.Start = local.End
+	otherDevice.End = local.End.Add(time.Hour)
+	legacy := otherDevice
+	legacy.UUID = ""
+
+	result := MergeThreeWay(nil, []models.Session{local}, []models.Session{local, otherDevice, legacy})
+
+	assert.Equal(t, []models.Session{otherDevice}, result.Added)
+	assert.Empty(t, result.Resolved())
+	assert.Empty(t, result.Conflicts.Local)
+}
@@ -34,6 +34,8 @@ func (s *SheetsSyncSource) parseSheetIfNeeded() error {
 	return nil
 }
 
+// Pull returns the sessions of the sheet, identified by UUID when the sheet
+// has a UUID column.
 func (s *SheetsSyncSource) Pull() ([]models.Session, error) {
 	err := s.parseSheetIfNeeded()
 	if err != nil {
@@ -45,7 +47,7 @@ func (s *SheetsSyncSource) Pull() ([]models.Session, error) {
 		sessions = append(sessions, record.Session)
 	}
 
-	return sessions, nil
+	return withLocalIDs(s.SessionRepository, sessions)
 }
 
 func (s *SheetsSyncSource) Push(sessions *[]models.Session, approvedDiffs *[]models.Session) (PushSummary, error) {
@@ -60,10 +62,15 @@ func (s *SheetsSyncSource) Push(sessions *[]models.Session, approvedDiffs *[]mod
 	var recordsToUpdate []*sheets.Record
 	var conflicts []models.Session
 
-	for session, record := range mappedSessions {
+	for _, mapped := range mappedSessions {
+		session, record := mapped.Session, mapped.Record
 		if record == nil {
 			sessionsToAdd = append(sessionsToAdd, session)
 		} else {
+			if sameUUID(record.Session, session) {
+				// the ID of the sheet may have been written by another device
+				record.Session.ID = session.ID
+			}
 			if record.Session.Conflicts(session) {
 				approved := false
 				for _, diff := range *approvedDiffs {
@@ -82,7 +89,8 @@ func (s *SheetsSyncSource) Push(sessions *[]models.Session, approvedDiffs *[]mod
 			} else if record.Session.ID != session.ID {
 				record.Session = session
 				recordsToUpdate = append(recordsToUpdate, record)
-			} else if record.Session.Equals(session) {
+			} else if recordMatchesSession(*record, session) &&
+				(sameUUID(record.Session, session) || !s.Sheet.HasUUIDColumn()) {
 				continue
 			} else {
 				record.Session = session
@@ -110,25 +118,90 @@ func (s *SheetsSyncSource) Push(sessions *[]models.Session, approvedDiffs *[]mod
 			return PushSummary{}, err
 		}
 	}
+	// the sheet is parsed again on the next pull, to read what it holds now
+	s.isDataFresh = false
 	return PushSummary{
 		Added:   len(sessionsToAdd),
 		Updated: len(recordsToUpdate),
 	}, nil
 }
 
-func mapSessionsToRecords(
-	sessions *[]models.Session,
-	records *[]sheets.Record) map[models.Session]*sheets.Record {
-	mappedSessions := make(map[models.Session]*sheets.Record)
+// Delete clears the rows of the given sessions, rows are matched by UUID, or
+// by ID when either has none, so that a similar session is never deleted by
+// mistake.
+func (s *SheetsSyncSource) Delete(sessions *[]models.Session) (PushSummary, error) {
+	err := s.parseSheetIfNeeded()
+	if err != nil {
+		return PushSummary{}, err
+	}
+
+	var summary PushSummary
 	for _, session := range *sessions {
-		mappedSessions[session] = nil
-		for _, record := range *records {
-			if (record.Session.ID == session.ID) ||
-				record.Session.Similar(session) {
-				mappedSessions[session] = &record
-				break
+		for _, record := range *s.cachedData {
+			if !sameRemoteSession(record.Session, session) {
+				continue
+			}
+			err := s.Sheet.ClearRow(record)
+			if err != nil {
+				return summary, err
 			}
+			summary.Deleted++
+			break
 		}
 	}
+	s.isDataFresh = false
+	return summary, nil
+}
+
+// recordMatchesSession compares a sheet record with a local session. Sheets
+// hold no projects, breaks or tags, breaks are only reflected through the
+// total time column.
+func recordMatchesSession(record sheets.Record, session models.Session) bool {
+	remoteSession := record.Session
+	remoteSession.Project = session.Project
+	remoteSession.Breaks = session.Breaks
+	remoteSession.Tags = session.Tags
+	if !remoteSession.Equals(session) {
+		return false
+	}
+	return len(session.Breaks) == 0 ||
+		!session.Finished() ||
+		record.TotalTime == session.Duration()
+}
+
+type sessionRecord struct {
+	Session models.Session
+	Record  *sheets.Record
+}
+
+func mapSessionsToRecords(
+	sessions *[]models.Session,
+	records *[]sheets.Record) []sessionRecord {
+	mappedSessions := make([]sessionRecord, 0, len(*sessions))
+	for _, session := range *sessions {
+		mappedSessions = append(mappedSessions, sessionRecord{
+			Session: session,
+			Record:  findRecord(records, session),
+		})
+	}
 	return mappedSessions
 }
+
+// findRecord returns the record with the UUID of the session. Records or
+// sessions without a UUID, from before sessions had one, fall back on the ID
+// or a similar session.
+func findRecord(records *[]sheets.Record, session models.Session) *sheets.Record {
+	var fallback *sheets.Record
+	for i := range *records {
+		record := &(*records)[i]
+		if sameUUID(record.Session, session) {
+			return record
+		}
+		if fallback == nil &&
+			(record.Session.UUID == "" || session.UUID == "") &&
+			(record.Session.ID == session.ID || record.Session.Similar(session)) {
+			fallback = record
+		}
+	}
+	return fallback
+}
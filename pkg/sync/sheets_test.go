@@ -0,0 +1,127 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dormunis/punch/pkg/models"
+	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/dormunis/punch/pkg/sync/adapters/sheets"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+	sheetsapi "google.golang.org/api/sheets/v4"
+)
+
+// newFakeSheet serves the given rows as the values of Sheet1, and records the
+// ranges that are cleared.
+func newFakeSheet(t *testing.T, rows [][]any, cleared *[]string) *sheets.Sheet {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if strings.HasSuffix(path, ":clear") {
+			*cleared = append(*cleared, strings.TrimSuffix(path[strings.LastIndex(path, "/")+1:], ":clear"))
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"values": rows})
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := sheetsapi.NewService(context.Background(),
+		option.WithEndpoint(server.URL),
+		option.WithHTTPClient(server.Client()))
+	assert.NoError(t, err)
+	return &sheets.Sheet{
+		Service:       service,
+		SpreadsheetId: "spreadsheet",
+		SheetName:     "Sheet1",
+		Columns: sheets.Columns{
+			ID:        "ID",
+			UUID:      "UUID",
+			Client:    "Client",
+			Date:      "Date",
+			StartTime: "Start Time",
+			EndTime:   "End Time",
+			TotalTime: "Total Time",
+			Note:      "Note",
+		},
+	}
+}
+
+func TestSheetsSyncSource_DeletesSessionDeletedLocallyByUUID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockRepo := repositories.NewMockSessionRepository(mockCtrl)
+
+	var cleared []string
+	sheet := newFakeSheet(t, [][]any{
+		{"ID", "UUID", "Client", "Date", "Start Time", "End Time", "Total Time", "Note"},
+		{"7", "another-device-uuid", "Initech", "11/10/2026", "09:00:00", "10:00:00", "01:00:00", ""},
+		{"7", "deleted-uuid", "Acme", "12/10/2026", "09:00:00", "10:00:00", "01:00:00", ""},
+	}, &cleared)
+	source := &SheetsSyncSource{Sheet: sheet, SessionRepository: mockRepo}
+
+	mockRepo.EXPECT().
+		GetAllSessionsAllClients().
+		Return(&[]models.Session{}, nil).
+		Times(1)
+	mockRepo.EXPECT().
+		GetTombstones().
+		Return([]models.Tombstone{{SessionID: 3, SessionUUID: "deleted-uuid"}}, nil).
+		Times(1)
+
+	pulled, err := source.Pull()
+	assert.NoError(t, err)
+	assert.Len(t, pulled, 2)
+	assert.Equal(t, uint32(0), pulled[0].ID, "sessions of other devices get no ID")
+	assert.Equal(t, uint32(3), pulled[1].ID, "deleted sessions keep their local ID")
+
+	summary, err := source.Delete(&[]models.Session{pulled[1]})
+
+	assert.NoError(t, err)
+	assert.Equal(t, PushSummary{Deleted: 1}, summary)
+	assert.Equal(t, []string{"Sheet1!3:3"}, cleared)
+}
+
+func TestMapSessionsToRecords_MatchesByUUID(t *testing.T) {
+	sessions := sampleFileSessions()
+	sessions[0].UUID = "local-uuid"
+	sessions[1].UUID = "other-uuid"
+
+	otherDevice := sessions[0]
+	otherDevice.UUID = "another-device-uuid"
+	legacy := sessions[1]
+	legacy.UUID = ""
+	moved := sessions[0]
+	moved.ID = 42
+	moved.Start = moved.Start.AddDate(0, 0, 3)
+	records := []sheets.Record{
+		{Session: otherDevice, Row: 1},
+		{Session: legacy, Row: 2},
+		{Session: moved, Row: 3},
+	}
+
+	mapped := mapSessionsToRecords(&sessions, &records)
+
+	assert.Len(t, mapped, 2)
+	assert.Equal(t, 3, mapped[0].Record.Row)
+	assert.Equal(t, 2, mapped[1].Record.Row)
+}
+
+func TestMapSessionsToRecords_CollidingIDFromAnotherDeviceIsNotMatched(t *testing.T) {
+	sessions := sampleFileSessions()[:1]
+	sessions[0].UUID = "local-uuid"
+	otherDevice := sessions[0]
+	otherDevice.UUID = "another-device-uuid"
+	otherDevice.Client = models.Client{Name: "Initech"}
+	records := []sheets.Record{{Session: otherDevice, Row: 1}}
+
+	mapped := mapSessionsToRecords(&sessions, &records)
+
+	assert.Len(t, mapped, 1)
+	assert.Nil(t, mapped[0].Record)
+}
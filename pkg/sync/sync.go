@@ -2,16 +2,20 @@ package sync
 
 import (
 	"errors"
+	"slices"
 
 	"github.com/dormunis/punch/pkg/config"
 	"github.com/dormunis/punch/pkg/models"
 	"github.com/dormunis/punch/pkg/repositories"
+	"github.com/dormunis/punch/pkg/sync/adapters/file"
+	"github.com/dormunis/punch/pkg/sync/adapters/git"
 	"github.com/dormunis/punch/pkg/sync/adapters/sheets"
 )
 
 type PushSummary struct {
 	Added   int
 	Updated int
+	Deleted int
 	Errors  []error
 }
 
@@ -19,6 +23,7 @@ type SyncSource interface {
 	Type() string
 	Pull() ([]models.Session, error)
 	Push(*[]models.Session, *[]models.Session) (PushSummary, error)
+	Delete(*[]models.Session) (PushSummary, error)
 }
 
 var (
@@ -46,8 +51,83 @@ func NewSource(remoteConfig config.Remote, sessionRepository repositories.Sessio
 			Sheet:             client,
 			SessionRepository: sessionRepository,
 		}, nil
+	case "file":
+		remoteFileConfig, ok := remoteConfig.(*config.FileRemote)
+		if !ok {
+			return nil, ErrInvalidRemoteConfig
+		}
+		remoteFile, err := file.NewFile(*remoteFileConfig)
+		if err != nil {
+			return nil, err
+		}
+		return &FileSyncSource{
+			File:              remoteFile,
+			SessionRepository: sessionRepository,
+		}, nil
+	case "git":
+		remoteGitConfig, ok := remoteConfig.(*config.GitRemote)
+		if !ok {
+			return nil, ErrInvalidRemoteConfig
+		}
+		repository, err := git.NewRepository(*remoteGitConfig)
+		if err != nil {
+			return nil, err
+		}
+		return &GitSyncSource{
+			Repository:        repository,
+			SessionRepository: sessionRepository,
+		}, nil
 	default:
 		return nil, ErrRemoteSourceNotSupported
 
 	}
 }
+
+// withLocalIDs gives the remote sessions that have a UUID the ID of the local
+// or deleted session with the same UUID, or no ID when there is none, since
+// IDs written by other devices may collide with local ones.
+func withLocalIDs(sessionRepository repositories.SessionRepository, remoteSessions []models.Session) ([]models.Session, error) {
+	sessions := append([]models.Session(nil), remoteSessions...)
+	if !slices.ContainsFunc(sessions, func(session models.Session) bool { return session.UUID != "" }) {
+		return sessions, nil
+	}
+
+	localSessions, err := sessionRepository.GetAllSessionsAllClients()
+	if err != nil {
+		return nil, err
+	}
+	tombstones, err := sessionRepository.GetTombstones()
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]uint32)
+	for _, tombstone := range tombstones {
+		if tombstone.SessionUUID != "" {
+			ids[tombstone.SessionUUID] = tombstone.SessionID
+		}
+	}
+	for _, session := range *localSessions {
+		if session.UUID != "" {
+			ids[session.UUID] = session.ID
+		}
+	}
+	for i := range sessions {
+		if sessions[i].UUID != "" {
+			sessions[i].ID = ids[sessions[i].UUID]
+		}
+	}
+	return sessions, nil
+}
+
+// sameRemoteSession reports whether a remote session is the given one, they
+// are matched by UUID, or by ID when either has no UUID.
+func sameRemoteSession(remote, session models.Session) bool {
+	if remote.UUID != "" && session.UUID != "" {
+		return remote.UUID == session.UUID
+	}
+	return remote.ID != 0 && remote.ID == session.ID
+}
+
+func sameUUID(a, b models.Session) bool {
+	return a.UUID != "" && a.UUID == b.UUID
+}